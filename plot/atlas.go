@@ -0,0 +1,131 @@
+package plot
+
+import (
+	"fmt"
+	"image"
+	_ "image/png" // Register the PNG format for loading atlases.
+	"os"
+	"strconv"
+
+	px "github.com/gopxl/pixel/v2"
+)
+
+// Atlas is a texture atlas, holding named frames of a sprite sheet image.
+//
+// Frames are used as sprites by [Entities], or by user drawers via [Atlas.Sprite].
+// As all frames share the same picture, sprites of an atlas can be drawn in one batch (see [px.Batch]).
+type Atlas struct {
+	picture *px.PictureData
+	names   []string
+	sprites map[string]*px.Sprite
+}
+
+// NewAtlas creates a new atlas from an image, without any frames.
+// Frames are added using [Atlas.Add] and [Atlas.AddGrid].
+func NewAtlas(img image.Image) *Atlas {
+	return &Atlas{
+		picture: px.PictureDataFromImage(img),
+		sprites: map[string]*px.Sprite{},
+	}
+}
+
+// LoadAtlas loads an atlas from a PNG image file, without any frames.
+// Frames are added using [Atlas.Add] and [Atlas.AddGrid].
+func LoadAtlas(path string) (*Atlas, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+	return NewAtlas(img), nil
+}
+
+// Add a named frame, given by its region in image coordinates, with the origin at the top left.
+// Panics if the name is already in use, or if the frame is not within the image.
+func (a *Atlas) Add(name string, frame image.Rectangle) {
+	if _, ok := a.sprites[name]; ok {
+		panic(fmt.Sprintf("duplicate frame name '%s' in atlas", name))
+	}
+	bounds := a.picture.Bounds()
+	if !frame.In(image.Rect(0, 0, int(bounds.W()), int(bounds.H()))) {
+		panic(fmt.Sprintf("frame '%s' is out of the atlas image bounds", name))
+	}
+	rect := px.R(
+		bounds.Min.X+float64(frame.Min.X), bounds.Max.Y-float64(frame.Max.Y),
+		bounds.Min.X+float64(frame.Max.X), bounds.Max.Y-float64(frame.Min.Y),
+	)
+	a.names = append(a.names, name)
+	a.sprites[name] = px.NewSprite(a.picture, rect)
+}
+
+// AddGrid adds frames of a sprite sheet that is arranged in a regular grid of frames with the given size.
+//
+// Frames are added row by row, starting at the top left, and are named by the given names.
+// If no names are given, frames are named by their index, starting at "0".
+// Panics if width or height is not positive, or if more names are given than there are frames in the image.
+func (a *Atlas) AddGrid(width, height int, names ...string) {
+	if width <= 0 || height <= 0 {
+		panic(fmt.Sprintf("atlas frame size must be positive, got %dx%d", width, height))
+	}
+	bounds := a.picture.Bounds()
+	cols, rows := int(bounds.W())/width, int(bounds.H())/height
+	if len(names) > cols*rows {
+		panic(fmt.Sprintf("got %d frame names, but atlas image has only %d frames", len(names), cols*rows))
+	}
+	count := len(names)
+	if count == 0 {
+		count = cols * rows
+	}
+	for i := 0; i < count; i++ {
+		name := strconv.Itoa(i)
+		if len(names) > 0 {
+			name = names[i]
+		}
+		x, y := (i%cols)*width, (i/cols)*height
+		a.Add(name, image.Rect(x, y, x+width, y+height))
+	}
+}
+
+// Sprite returns the sprite for the frame with the given name.
+// Panics if there is no such frame.
+func (a *Atlas) Sprite(name string) *px.Sprite {
+	sprite, ok := a.sprites[name]
+	if !ok {
+		panic(fmt.Sprintf("no frame '%s' in atlas", name))
+	}
+	return sprite
+}
+
+// Has returns whether the atlas has a frame with the given name.
+func (a *Atlas) Has(name string) bool {
+	_, ok := a.sprites[name]
+	return ok
+}
+
+// Names returns the names of all frames, in the order they were added.
+func (a *Atlas) Names() []string {
+	return a.names
+}
+
+// Picture returns the picture of the atlas, shared by all its sprites.
+func (a *Atlas) Picture() px.Picture {
+	return a.picture
+}
+
+// Animation is a sequence of frame names of an [Atlas], for simple animations.
+type Animation []string
+
+// Frame returns the frame name for the given animation step.
+// Steps beyond the length of the animation wrap around.
+func (a Animation) Frame(step int) string {
+	step %= len(a)
+	if step < 0 {
+		step += len(a)
+	}
+	return a[step]
+}
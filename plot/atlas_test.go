@@ -0,0 +1,152 @@
+package plot_test
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/plot"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleAtlas() {
+	// Create a new model.
+	m := model.New()
+
+	// Limit the the simulation speed.
+	m.TPS = 30
+
+	// Create an atlas from a sprite sheet image, with 4 frames of 16x16 pixels in a row.
+	// Usually, the atlas is loaded from a PNG file, using plot.LoadAtlas.
+	atlas := plot.NewAtlas(image.NewRGBA(image.Rect(0, 0, 64, 16)))
+	atlas.AddGrid(16, 16, "walk-1", "walk-2", "walk-3", "walk-4")
+	walk := plot.Animation{"walk-1", "walk-2", "walk-3", "walk-4"}
+
+	// Create some entities, with an animation step that would be updated by a system.
+	builder := generic.NewMap2[Position, AnimationStep](&m.World)
+	builder.NewWith(&Position{X: 100, Y: 100}, &AnimationStep{Step: 0})
+	builder.NewWith(&Position{X: 200, Y: 150}, &AnimationStep{Step: 2})
+
+	// Draw entities as sprites, with the animation frame derived from the animation step.
+	entities := plot.Entities[Position]{
+		Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+		Shape:    plot.ShapeSprite,
+		Atlas:    atlas,
+		Frame:    plot.FromComponent(func(a *AnimationStep) string { return walk.Frame(a.Step) }),
+	}
+	m.AddUISystem((&window.Window{}).With(&entities))
+
+	// Add a termination system that ends the simulation.
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+
+	// Run the simulation.
+	// Due to the use of the OpenGL UI system, the model must be run via [window.Run].
+	// Comment out the code line above, and uncomment the next line to run this example stand-alone.
+
+	// window.Run(m)
+
+	// Output:
+}
+
+func TestAtlas(t *testing.T) {
+	atlas := plot.NewAtlas(image.NewRGBA(image.Rect(0, 0, 32, 24)))
+	atlas.AddGrid(8, 8)
+	assert.Equal(t, 12, len(atlas.Names()))
+	assert.Equal(t, "0", atlas.Names()[0])
+	assert.Equal(t, "11", atlas.Names()[11])
+
+	assert.Equal(t, px.R(0, 16, 8, 24), atlas.Sprite("0").Frame())
+	assert.Equal(t, px.R(8, 16, 16, 24), atlas.Sprite("1").Frame())
+	assert.Equal(t, px.R(0, 8, 8, 16), atlas.Sprite("4").Frame())
+
+	atlas.Add("wide", image.Rect(0, 0, 16, 8))
+	assert.True(t, atlas.Has("wide"))
+	assert.False(t, atlas.Has("missing"))
+	assert.Equal(t, px.R(0, 16, 16, 24), atlas.Sprite("wide").Frame())
+	assert.Equal(t, atlas.Picture(), atlas.Sprite("wide").Picture())
+
+	assert.Panics(t, func() { atlas.Sprite("missing") })
+	assert.Panics(t, func() { atlas.Add("wide", image.Rect(0, 0, 8, 8)) })
+	assert.Panics(t, func() { atlas.Add("outside", image.Rect(24, 0, 40, 8)) })
+	assert.Panics(t, func() { atlas.AddGrid(16, 16, "a", "b", "c") })
+	assert.PanicsWithValue(t, "atlas frame size must be positive, got 0x8", func() { atlas.AddGrid(0, 8) })
+	assert.PanicsWithValue(t, "atlas frame size must be positive, got 8x-8", func() { atlas.AddGrid(8, -8) })
+}
+
+func TestLoadAtlas(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sheet.png")
+	file, err := os.Create(path)
+	assert.Nil(t, err)
+	assert.Nil(t, png.Encode(file, image.NewRGBA(image.Rect(0, 0, 32, 16))))
+	assert.Nil(t, file.Close())
+
+	atlas, err := plot.LoadAtlas(path)
+	assert.Nil(t, err)
+	atlas.AddGrid(16, 16, "a", "b")
+	assert.Equal(t, []string{"a", "b"}, atlas.Names())
+
+	_, err = plot.LoadAtlas(filepath.Join(t.TempDir(), "missing.png"))
+	assert.NotNil(t, err)
+}
+
+func TestAnimation(t *testing.T) {
+	anim := plot.Animation{"a", "b", "c"}
+	assert.Equal(t, "a", anim.Frame(0))
+	assert.Equal(t, "c", anim.Frame(2))
+	assert.Equal(t, "b", anim.Frame(4))
+	assert.Equal(t, "c", anim.Frame(-1))
+}
+
+func TestEntities_Atlas(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+
+	atlas := plot.NewAtlas(image.NewRGBA(image.Rect(0, 0, 32, 16)))
+	atlas.AddGrid(16, 16, "a", "b")
+
+	builder := generic.NewMap2[Position, Rotation](&m.World)
+	builder.NewWith(&Position{X: 100, Y: 100}, &Rotation{Angle: 1})
+	posBuilder := generic.NewMap1[Position](&m.World)
+	posBuilder.NewWith(&Position{X: 200, Y: 100})
+
+	m.AddUISystem((&window.Window{}).
+		With(&plot.Entities[Position]{
+			Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+			Shape:    plot.ShapeSprite,
+			Atlas:    atlas,
+			Frame:    plot.FromComponent(func(r *Rotation) string { return "b" }),
+		}))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+	m.Run()
+
+	m = model.New()
+	m.AddUISystem((&window.Window{}).
+		With(&plot.Entities[Position]{
+			Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+			Shape:    plot.ShapeSprite,
+			Atlas:    plot.NewAtlas(image.NewRGBA(image.Rect(0, 0, 32, 16))),
+		}))
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	assert.Panics(t, m.Run)
+}
+
+// AnimationStep component for sprite animations.
+type AnimationStep struct {
+	Step int
+}
@@ -0,0 +1,67 @@
+package plot
+
+import (
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+)
+
+// Attribute provides a value of type V for entities, e.g. the heading, size or color for drawing them.
+//
+// Use [FromComponent] to create an attribute that is derived from a component.
+type Attribute[V any] interface {
+	// Initialize the attribute.
+	Initialize(w *ecs.World)
+	// Get the value for an entity. Returns false if the entity has no value.
+	Get(e ecs.Entity) (V, bool)
+}
+
+// FromComponent creates an [Attribute] that derives values from a component of type C, using the given function.
+// Entities that don't have the component have no value.
+//
+// Example:
+//
+//	heading := plot.FromComponent(func(h *Heading) float64 { return h.Angle })
+func FromComponent[C any, V any](fn func(c *C) V) Attribute[V] {
+	return &componentAttribute[C, V]{get: fn}
+}
+
+// componentAttribute is an [Attribute] derived from a component.
+type componentAttribute[C any, V any] struct {
+	get    func(c *C) V
+	mapper generic.Map[C]
+}
+
+// Initialize the attribute.
+func (a *componentAttribute[C, V]) Initialize(w *ecs.World) {
+	a.mapper = generic.NewMap[C](w)
+}
+
+// Get the value for an entity.
+func (a *componentAttribute[C, V]) Get(e ecs.Entity) (V, bool) {
+	if !a.mapper.Has(e) {
+		var zero V
+		return zero, false
+	}
+	return a.get(a.mapper.Get(e)), true
+}
+
+// FromFunc creates an [Attribute] that derives values from entities using the given function.
+// All entities have a value.
+//
+// Example:
+//
+//	label := plot.FromFunc(func(e ecs.Entity) string { return fmt.Sprintf("#%d", e.ID()) })
+func FromFunc[V any](fn func(e ecs.Entity) V) Attribute[V] {
+	return funcAttribute[V](fn)
+}
+
+// funcAttribute is an [Attribute] derived from a function.
+type funcAttribute[V any] func(e ecs.Entity) V
+
+// Initialize the attribute.
+func (a funcAttribute[V]) Initialize(w *ecs.World) {}
+
+// Get the value for an entity.
+func (a funcAttribute[V]) Get(e ecs.Entity) (V, bool) {
+	return a(e), true
+}
@@ -2,17 +2,13 @@ package plot
 
 import (
 	"fmt"
-	"image/color"
 
-	pixel "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/mlange-42/arche-model/observer"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
-	"gonum.org/v1/plot/vg/draw"
-	"gonum.org/v1/plot/vg/vgimg"
 )
 
 // Bars plot drawer.
@@ -31,7 +27,7 @@ type Bars struct {
 }
 
 // Initialize the drawer.
-func (b *Bars) Initialize(w *ecs.World, win *opengl.Window) {
+func (b *Bars) Initialize(w *ecs.World, ctx *window.Context) {
 	b.Observer.Initialize(w)
 
 	headers := b.Observer.Header()
@@ -68,19 +64,24 @@ func (b *Bars) Update(w *ecs.World) {
 }
 
 // UpdateInputs handles input events of the previous frame update.
-func (b *Bars) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (b *Bars) UpdateInputs(w *ecs.World, ctx *window.Context) {}
 
 // Draw the drawer.
-func (b *Bars) Draw(w *ecs.World, win *opengl.Window) {
-	width := win.Canvas().Bounds().W()
-	height := win.Canvas().Bounds().H()
-
+func (b *Bars) Draw(w *ecs.World, ctx *window.Context) {
 	b.updateData(w)
+	renderPlot(b.buildPlot(ctx), ctx, b.scale)
+}
+
+// ExportVector writes the plot to a vector graphics file.
+func (b *Bars) ExportVector(w *ecs.World, ctx *window.Context, path string) error {
+	return savePlot(b.buildPlot(ctx), ctx, b.scale, path)
+}
 
-	c := vgimg.New(vg.Points(width*b.scale)-10, vg.Points(height*b.scale)-10)
+// buildPlot creates the plot from the current data.
+func (b *Bars) buildPlot(ctx *window.Context) *plot.Plot {
+	width := ctx.Bounds.W()
 
-	p := plot.New()
-	setLabels(p, b.Labels)
+	p := newPlot(ctx, b.Labels)
 
 	if b.YLim[0] != 0 || b.YLim[1] != 0 {
 		p.Y.Min = b.YLim[0]
@@ -92,18 +93,11 @@ func (b *Bars) Draw(w *ecs.World, win *opengl.Window) {
 	if err != nil {
 		panic(err)
 	}
-	bars.Color = defaultColors[0]
+	bars.Color = ctx.Theme.SeriesColor(0)
 	p.Add(bars)
 	p.NominalX(b.headers...)
 
-	win.Clear(color.White)
-	p.Draw(draw.New(c))
-
-	img := c.Image()
-	picture := pixel.PictureDataFromImage(img)
-
-	sprite := pixel.NewSprite(picture, picture.Bounds())
-	sprite.Draw(win, pixel.IM.Moved(pixel.V(picture.Rect.W()/2.0+5, picture.Rect.H()/2.0+5)))
+	return p
 }
 
 func (b *Bars) updateData(w *ecs.World) {
@@ -0,0 +1,91 @@
+package plot_test
+
+import (
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/plot"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/colornames"
+)
+
+func TestBindings_Conflicts(t *testing.T) {
+	bindings := runBindings(nil)
+
+	assert.Greater(t, len(bindings.Actions()), 30)
+
+	conflicts := bindings.Conflicts()
+	assert.Equal(t, 6, len(conflicts))
+	for _, key := range []px.Button{px.KeyF, px.KeyT, px.KeyV, px.KeyN, px.KeyUp, px.KeyDown} {
+		assert.Equal(t, 3, len(conflicts[key]))
+	}
+}
+
+func TestBindings_Remap(t *testing.T) {
+	bindings := runBindings(map[string]px.Button{
+		"resources.fields":      px.KeyG,
+		"resources.types":       px.KeyY,
+		"resources.values":      px.KeyB,
+		"resources.names":       px.KeyM,
+		"resources.scroll-up":   px.KeyPageUp,
+		"resources.scroll-down": px.KeyPageDown,
+		"systems.fields":        px.KeyJ,
+		"systems.types":         px.KeyK,
+		"systems.values":        px.KeyO,
+		"systems.names":         px.KeyH,
+		"systems.scroll-up":     px.KeyW,
+		"systems.scroll-down":   px.KeyS,
+	})
+
+	assert.Empty(t, bindings.Conflicts())
+}
+
+// runBindings runs a model with all drawers that register keys, and returns the window's bindings.
+func runBindings(keys map[string]px.Button) *window.Bindings {
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	probe := bindingsProbe{}
+	m.AddUISystem((&window.Window{Keys: keys}).
+		With(
+			(&window.Tabs{}).With(
+				window.Tab{Drawer: &plot.Inspector{}},
+				window.Tab{Drawer: &plot.Resources{}},
+				window.Tab{Drawer: &plot.Systems{}},
+			),
+			(&window.Camera{}).With(
+				&plot.TileMap{
+					Observer: &LandUseObserver{},
+					Classes:  []plot.TileClass{{Name: "Water", Color: colornames.Steelblue}},
+				},
+				&plot.Trails[Position]{
+					Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+				},
+			),
+			&plot.Controls{},
+			&window.Recorder{},
+			&probe,
+		))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 1,
+	})
+	m.Run()
+
+	return probe.bindings
+}
+
+// bindingsProbe is a drawer that captures the key bindings of the window.
+type bindingsProbe struct {
+	bindings *window.Bindings
+}
+
+func (p *bindingsProbe) Initialize(w *ecs.World, ctx *window.Context)   { p.bindings = ctx.Bindings }
+func (p *bindingsProbe) Update(w *ecs.World)                            {}
+func (p *bindingsProbe) UpdateInputs(w *ecs.World, ctx *window.Context) {}
+func (p *bindingsProbe) Draw(w *ecs.World, ctx *window.Context)         {}
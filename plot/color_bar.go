@@ -0,0 +1,279 @@
+package plot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/gopxl/pixel/v2/ext/text"
+	"github.com/mlange-42/arche-pixel/window"
+)
+
+const (
+	colorBarWidth   = 14.0 // Thickness of a color bar, in pixels.
+	colorBarGap     = 8.0  // Gap between image, bars and labels, in pixels.
+	colorBarTick    = 4.0  // Length of tick marks, in pixels.
+	colorBarSamples = 256  // Number of color samples along a bar.
+)
+
+// ColorBarPosition is the position of a [ColorBar] relative to the image.
+type ColorBarPosition uint8
+
+const (
+	ColorBarRight  ColorBarPosition = iota // Right of the image.
+	ColorBarLeft                           // Left of the image.
+	ColorBarBottom                         // Below the image.
+	ColorBarTop                            // Above the image.
+)
+
+// ColorBar is a legend for the color mapping of an [Image] or [ImageRGB], drawn next to the image.
+//
+// The image is scaled down to leave space for the color bar.
+// For [ImageRGB], a bar is drawn for each channel.
+type ColorBar struct {
+	Position ColorBarPosition // Position relative to the image. Optional, default right.
+	Ticks    int              // Number of labelled ticks, including minimum and maximum. Optional, default 5.
+	Label    string           // Label of the color bar. Optional.
+	Format   string           // Format of tick labels, see package fmt. Optional, default "%.3g".
+	Font     *text.Atlas      // Font for text. Optional, default the window's font.
+	drawer   imdraw.IMDraw
+	text     *text.Text
+	picture  *px.PictureData
+}
+
+// colorBarChannel is a single bar of a [ColorBar].
+type colorBarChannel struct {
+	Name  string                     // Name shown above the bar.
+	Min   float64                    // Value at the start of the bar.
+	Max   float64                    // Value at the end of the bar.
+	Log   bool                       // Whether values are spaced logarithmically along the bar.
+	Color func(v float64) color.RGBA // Color of a value.
+}
+
+// value returns the value at the given fraction of the bar's length.
+func (ch *colorBarChannel) value(t float64) float64 {
+	if ch.Log {
+		return math.Exp(math.Log(ch.Min) + (math.Log(ch.Max)-math.Log(ch.Min))*t)
+	}
+	return ch.Min + (ch.Max-ch.Min)*t
+}
+
+// initialize sets defaults and prepares drawing.
+func (b *ColorBar) initialize(ctx *window.Context) {
+	if b.Ticks == 0 {
+		b.Ticks = 5
+	}
+	if b.Ticks < 2 {
+		panic("color bar needs at least 2 ticks")
+	}
+	if b.Format == "" {
+		b.Format = "%.3g"
+	}
+	b.drawer = *imdraw.New(nil)
+	b.text = text.New(px.V(0, 0), fontOr(b.Font, ctx))
+	b.picture = px.MakePictureData(px.R(0, 0, colorBarSamples, 1))
+}
+
+// vertical returns whether the bars are drawn vertically, i.e. left or right of the image.
+func (b *ColorBar) vertical() bool {
+	return b.Position == ColorBarRight || b.Position == ColorBarLeft
+}
+
+// ticks returns the formatted tick labels of a channel.
+func (b *ColorBar) ticks(ch *colorBarChannel) []string {
+	labels := make([]string, b.Ticks)
+	for k := range labels {
+		labels[k] = fmt.Sprintf(b.Format, b.tickValue(ch, k))
+	}
+	return labels
+}
+
+// tickValue returns the value of the tick with the given index.
+func (b *ColorBar) tickValue(ch *colorBarChannel, k int) float64 {
+	return ch.value(float64(k) / float64(b.Ticks-1))
+}
+
+// columnWidth returns the width of a vertical bar with its tick labels and name.
+func (b *ColorBar) columnWidth(ch *colorBarChannel) float64 {
+	width := b.text.BoundsOf(ch.Name).W()
+	for _, l := range b.ticks(ch) {
+		width = math.Max(width, colorBarWidth+colorBarTick+2+b.text.BoundsOf(l).W())
+	}
+	return width
+}
+
+// hasNames returns whether any of the channels has a name.
+func hasNames(channels []colorBarChannel) bool {
+	for i := range channels {
+		if channels[i].Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// extent returns the space required by the color bar, perpendicular to the bars.
+func (b *ColorBar) extent(channels []colorBarChannel) float64 {
+	lh := b.text.LineHeight
+	if b.vertical() {
+		width := colorBarGap
+		for i := range channels {
+			width += b.columnWidth(&channels[i]) + colorBarGap
+		}
+		return math.Max(width, 2*colorBarGap+b.text.BoundsOf(b.Label).W())
+	}
+	height := colorBarGap
+	if b.Label != "" {
+		height += lh
+	}
+	for i := range channels {
+		if channels[i].Name != "" {
+			height += lh
+		}
+		height += colorBarWidth + colorBarTick + lh + colorBarGap
+	}
+	return height
+}
+
+// imageBounds returns the region available for the image, leaving space for the color bar.
+func (b *ColorBar) imageBounds(bounds px.Rect, channels []colorBarChannel) px.Rect {
+	extent := b.extent(channels)
+	switch b.Position {
+	case ColorBarLeft:
+		bounds.Min.X += extent
+	case ColorBarBottom:
+		bounds.Min.Y += extent
+	case ColorBarTop:
+		bounds.Max.Y -= extent
+	default:
+		bounds.Max.X -= extent
+	}
+	return bounds
+}
+
+// draw draws the color bar next to the given image rectangle, in pixel coordinates.
+func (b *ColorBar) draw(ctx *window.Context, image px.Rect, channels []colorBarChannel) {
+	extent := b.extent(channels)
+	var region px.Rect
+	switch b.Position {
+	case ColorBarLeft:
+		region = px.R(image.Min.X-extent, image.Min.Y, image.Min.X, image.Max.Y)
+	case ColorBarBottom:
+		region = px.R(image.Min.X, image.Min.Y-extent, image.Max.X, image.Min.Y)
+	case ColorBarTop:
+		region = px.R(image.Min.X, image.Max.Y, image.Max.X, image.Max.Y+extent)
+	default:
+		region = px.R(image.Max.X, image.Min.Y, image.Max.X+extent, image.Max.Y)
+	}
+
+	b.text.Clear()
+	b.text.Color = ctx.Theme.Foreground
+	b.drawer.Color = ctx.Theme.Foreground
+
+	if b.vertical() {
+		b.drawVertical(ctx, region, channels)
+	} else {
+		b.drawHorizontal(ctx, region, channels)
+	}
+
+	b.drawer.Draw(ctx)
+	b.drawer.Clear()
+	b.text.Draw(ctx, px.IM)
+}
+
+// drawVertical draws bars from bottom to top, with tick labels right of the bars.
+func (b *ColorBar) drawVertical(ctx *window.Context, region px.Rect, channels []colorBarChannel) {
+	lh := b.text.LineHeight
+	ascent := b.text.Atlas().Ascent()
+
+	top := region.Max.Y
+	x := region.Min.X + colorBarGap
+	if b.Label != "" {
+		b.write(px.V(x, top-ascent), b.Label)
+		top -= lh
+	}
+	nameY := top - ascent
+	if hasNames(channels) {
+		top -= lh
+	}
+
+	for i := range channels {
+		ch := &channels[i]
+		b.write(px.V(x, nameY), ch.Name)
+
+		bar := px.R(x, region.Min.Y, x+colorBarWidth, top-colorBarGap/2)
+		b.drawGradient(ctx, ch, bar, true)
+
+		for k, label := range b.ticks(ch) {
+			y := bar.Min.Y + bar.H()*float64(k)/float64(b.Ticks-1)
+			b.drawer.Push(px.V(bar.Max.X, y), px.V(bar.Max.X+colorBarTick, y))
+			b.drawer.Line(1)
+			b.write(px.V(bar.Max.X+colorBarTick+2, y-ascent/2), label)
+		}
+
+		x += b.columnWidth(ch) + colorBarGap
+	}
+}
+
+// drawHorizontal draws bars from left to right, with tick labels below the bars.
+func (b *ColorBar) drawHorizontal(ctx *window.Context, region px.Rect, channels []colorBarChannel) {
+	lh := b.text.LineHeight
+	ascent := b.text.Atlas().Ascent()
+
+	top := region.Max.Y - colorBarGap
+	if b.Label != "" {
+		b.write(px.V(region.Min.X, top-ascent), b.Label)
+		top -= lh
+	}
+
+	for i := range channels {
+		ch := &channels[i]
+		if ch.Name != "" {
+			b.write(px.V(region.Min.X, top-ascent), ch.Name)
+			top -= lh
+		}
+
+		bar := px.R(region.Min.X, top-colorBarWidth, region.Max.X, top)
+		b.drawGradient(ctx, ch, bar, false)
+
+		for k, label := range b.ticks(ch) {
+			x := bar.Min.X + bar.W()*float64(k)/float64(b.Ticks-1)
+			b.drawer.Push(px.V(x, bar.Min.Y), px.V(x, bar.Min.Y-colorBarTick))
+			b.drawer.Line(1)
+
+			width := b.text.BoundsOf(label).W()
+			lx := math.Min(math.Max(x-width/2, region.Min.X), region.Max.X-width)
+			b.write(px.V(lx, bar.Min.Y-colorBarTick-ascent), label)
+		}
+
+		top = bar.Min.Y - colorBarTick - lh - colorBarGap
+	}
+}
+
+// drawGradient draws the colors of a channel into the given rectangle.
+func (b *ColorBar) drawGradient(ctx *window.Context, ch *colorBarChannel, bar px.Rect, vertical bool) {
+	for k := range b.picture.Pix {
+		b.picture.Pix[k] = ch.Color(ch.value((float64(k) + 0.5) / colorBarSamples))
+	}
+
+	mat := px.IM.ScaledXY(px.ZV, px.V(bar.W()/colorBarSamples, bar.H()))
+	if vertical {
+		mat = px.IM.ScaledXY(px.ZV, px.V(bar.H()/colorBarSamples, bar.W())).Rotated(px.ZV, math.Pi/2)
+	}
+	sprite := px.NewSprite(b.picture, b.picture.Bounds())
+	sprite.Draw(ctx, mat.Moved(bar.Center()))
+
+	b.drawer.Push(bar.Min, bar.Max)
+	b.drawer.Rectangle(1)
+}
+
+// write writes a text with its baseline starting at the given position.
+func (b *ColorBar) write(pos px.Vec, s string) {
+	if s == "" {
+		return
+	}
+	b.text.Dot = pos
+	fmt.Fprint(b.text, s)
+}
@@ -0,0 +1,41 @@
+package plot
+
+import (
+	"image/color"
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorBar(t *testing.T) {
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), nil)
+
+	channels := []colorBarChannel{
+		{Min: -1, Max: 1, Color: func(v float64) color.RGBA { return color.RGBA{} }},
+	}
+
+	bar := ColorBar{}
+	bar.initialize(ctx)
+	assert.Equal(t, 5, bar.Ticks)
+	assert.Equal(t, []string{"-1", "-0.5", "0", "0.5", "1"}, bar.ticks(&channels[0]))
+
+	bounds := bar.imageBounds(ctx.Bounds, channels)
+	assert.Equal(t, 0.0, bounds.Min.X)
+	assert.Greater(t, bounds.Max.X, 700.0)
+	assert.Less(t, bounds.Max.X, 800.0)
+	assert.Equal(t, 600.0, bounds.H())
+
+	bar = ColorBar{Position: ColorBarBottom, Ticks: 3, Format: "%.2f"}
+	bar.initialize(ctx)
+	assert.Equal(t, []string{"-1.00", "0.00", "1.00"}, bar.ticks(&channels[0]))
+
+	bounds = bar.imageBounds(ctx.Bounds, channels)
+	assert.Equal(t, 800.0, bounds.W())
+	assert.Greater(t, bounds.Min.Y, 0.0)
+	assert.Equal(t, 600.0, bounds.Max.Y)
+
+	bar = ColorBar{Ticks: 1}
+	assert.Panics(t, func() { bar.initialize(ctx) })
+}
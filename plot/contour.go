@@ -4,16 +4,14 @@ import (
 	"fmt"
 	"image/color"
 
-	pixel "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/mlange-42/arche-model/observer"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/palette"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
-	"gonum.org/v1/plot/vg/vgimg"
 )
 
 // Contour plot drawer.
@@ -33,7 +31,7 @@ type Contour struct {
 }
 
 // Initialize the drawer.
-func (c *Contour) Initialize(w *ecs.World, win *opengl.Window) {
+func (c *Contour) Initialize(w *ecs.World, ctx *window.Context) {
 	c.Observer.Initialize(w)
 	c.data = plotGrid{
 		Grid: c.Observer,
@@ -47,19 +45,22 @@ func (c *Contour) Update(w *ecs.World) {
 }
 
 // UpdateInputs handles input events of the previous frame update.
-func (c *Contour) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (c *Contour) UpdateInputs(w *ecs.World, ctx *window.Context) {}
 
 // Draw the drawer.
-func (c *Contour) Draw(w *ecs.World, win *opengl.Window) {
-	width := win.Canvas().Bounds().W()
-	height := win.Canvas().Bounds().H()
-
+func (c *Contour) Draw(w *ecs.World, ctx *window.Context) {
 	c.updateData(w)
+	renderPlot(c.buildPlot(ctx), ctx, c.scale)
+}
 
-	canvas := vgimg.New(vg.Points(width*c.scale)-10, vg.Points(height*c.scale)-10)
+// ExportVector writes the plot to a vector graphics file.
+func (c *Contour) ExportVector(w *ecs.World, ctx *window.Context, path string) error {
+	return savePlot(c.buildPlot(ctx), ctx, c.scale, path)
+}
 
-	p := plot.New()
-	setLabels(p, c.Labels)
+// buildPlot creates the plot from the current data.
+func (c *Contour) buildPlot(ctx *window.Context) *plot.Plot {
+	p := newPlot(ctx, c.Labels)
 
 	p.X.Tick.Marker = removeLastTicks{}
 
@@ -85,21 +86,13 @@ func (c *Contour) Draw(w *ecs.World, win *opengl.Window) {
 	}
 
 	if !c.HideLegend {
-		p.Legend = plot.NewLegend()
-		p.Legend.TextStyle.Font.Variant = "Mono"
+		p.Legend = newLegend(ctx)
 		c.populateLegend(&p.Legend, &contours)
 	}
 
 	p.Add(&contours)
 
-	win.Clear(color.White)
-	p.Draw(draw.New(canvas))
-
-	img := canvas.Image()
-	picture := pixel.PictureDataFromImage(img)
-
-	sprite := pixel.NewSprite(picture, picture.Bounds())
-	sprite.Draw(win, pixel.IM.Moved(pixel.V(picture.Rect.W()/2.0+5, picture.Rect.H()/2.0+5)))
+	return p
 }
 
 func (c *Contour) updateData(w *ecs.World) {
@@ -2,14 +2,13 @@ package plot
 
 import (
 	"fmt"
-	"image/color"
 	"math"
 
 	px "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/gopxl/pixel/v2/ext/imdraw"
 	"github.com/gopxl/pixel/v2/ext/text"
 	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
 	"github.com/mlange-42/arche/generic"
 )
@@ -18,18 +17,24 @@ import (
 // UI controls are displayed in the bottom right corner of the window.
 //
 // Pause and resume the simulation via a button or by pressing SPACE.
-// Manipulate simulation speed (TPS) using buttons or UP/DOWN keys.
+// Manipulate simulation speed (TPS) using buttons or RIGHT/LEFT keys.
+// Press F1 to show all shortcuts.
+// Keys can be remapped via the actions "controls.pause", "controls.faster" and "controls.slower" (see [window.Bindings]).
 //
 // Expects a world resource of type Systems ([github.com/mlange-42/arche-model/model.Systems]).
 type Controls struct {
-	Scale      float64 // Spatial scaling: cell size in screen pixels. Optional, default 1.
+	Scale      float64     // Spatial scaling: cell size in screen pixels. Optional, default 1.
+	Font       *text.Atlas // Font for text. Optional, default the window's font.
 	drawer     imdraw.IMDraw
 	systemsRes generic.Resource[model.Systems]
 	text       *text.Text
+	pause      *window.Action
+	faster     *window.Action
+	slower     *window.Action
 }
 
 // Initialize the system
-func (c *Controls) Initialize(w *ecs.World, win *opengl.Window) {
+func (c *Controls) Initialize(w *ecs.World, ctx *window.Context) {
 	c.systemsRes = generic.NewResource[model.Systems](w)
 	if !c.systemsRes.Has() {
 		panic("resource of type Systems expected in Controls drawer")
@@ -40,34 +45,47 @@ func (c *Controls) Initialize(w *ecs.World, win *opengl.Window) {
 	}
 
 	c.drawer = *imdraw.New(nil)
-	c.text = text.New(px.V(0, 0), defaultFont)
+	c.text = text.New(px.V(0, 0), fontOr(c.Font, ctx))
+	c.text.Color = ctx.Theme.Foreground
 
+	c.pause = ctx.Bindings.Register("controls.pause", px.KeySpace, "Pause or resume the simulation")
+	c.faster = ctx.Bindings.Register("controls.faster", px.KeyRight, "Increase simulation speed")
+	c.slower = ctx.Bindings.Register("controls.slower", px.KeyLeft, "Decrease simulation speed")
+}
+
+// Shortcuts returns the drawer's keyboard and mouse shortcuts.
+func (c *Controls) Shortcuts() []window.Shortcut {
+	return []window.Shortcut{
+		c.pause.Shortcut(),
+		c.faster.Shortcut(),
+		c.slower.Shortcut(),
+		{Keys: "Mouse click", Description: "Use buttons"},
+	}
 }
 
 // Update the drawer.
 func (c *Controls) Update(w *ecs.World) {}
 
 // UpdateInputs handles input events of the previous frame update.
-func (c *Controls) UpdateInputs(w *ecs.World, win *opengl.Window) {
+func (c *Controls) UpdateInputs(w *ecs.World, ctx *window.Context) {
 	sys := c.systemsRes.Get()
-	if win.JustPressed(px.KeySpace) {
+	if ctx.JustPressed(c.pause.Key) {
 		sys.Paused = !sys.Paused
 		return
 	}
-	if win.JustPressed(px.KeyUp) {
+	if ctx.JustPressed(c.faster.Key) {
 		sys.TPS = calcTps(sys.TPS, true)
 		return
 	}
-	if win.JustPressed(px.KeyDown) {
+	if ctx.JustPressed(c.slower.Key) {
 		sys.TPS = calcTps(sys.TPS, false)
 		return
 	}
 
-	if win.JustPressed(px.MouseButton1) {
-		width := win.Canvas().Bounds().W()
-		height := win.Canvas().Bounds().H()
+	if ctx.JustPressed(px.MouseButton1) {
+		width, height := ctx.Bounds.W(), ctx.Bounds.H()
 
-		mouse := win.MousePosition()
+		mouse := ctx.MousePosition()
 		if c.pauseBounds(width, height).Contains(mouse.X, mouse.Y) {
 			sys.Paused = !sys.Paused
 		} else if c.upButton(width, height).Contains(mouse.X, mouse.Y) {
@@ -78,37 +96,48 @@ func (c *Controls) UpdateInputs(w *ecs.World, win *opengl.Window) {
 	}
 }
 
+// Hit checks whether the given position is on one of the buttons.
+// Mouse events on the buttons are not passed to drawers below Controls.
+func (c *Controls) Hit(ctx *window.Context, pos px.Vec) bool {
+	width, height := ctx.Bounds.W(), ctx.Bounds.H()
+	for _, b := range []*button{c.pauseBounds(width, height), c.upButton(width, height), c.downButton(width, height), c.tpsButton(width, height)} {
+		if b.Contains(pos.X, pos.Y) {
+			return true
+		}
+	}
+	return false
+}
+
 // Draw the system
-func (c *Controls) Draw(w *ecs.World, win *opengl.Window) {
-	width := win.Canvas().Bounds().W()
-	height := win.Canvas().Bounds().H()
+func (c *Controls) Draw(w *ecs.World, ctx *window.Context) {
+	width, height := ctx.Bounds.W(), ctx.Bounds.H()
 
 	sys := c.systemsRes.Get()
 	text := "Pause"
 	if sys.Paused {
 		text = "Resume"
 	}
-	c.drawButton(c.pauseBounds(width, height), text, win)
+	c.drawButton(c.pauseBounds(width, height), text, ctx)
 
-	c.drawButton(c.upButton(width, height), "+", win)
-	c.drawButton(c.downButton(width, height), "-", win)
-	c.drawButton(c.tpsButton(width, height), fmt.Sprintf("%.0f TPS", sys.TPS), win)
+	c.drawButton(c.upButton(width, height), "+", ctx)
+	c.drawButton(c.downButton(width, height), "-", ctx)
+	c.drawButton(c.tpsButton(width, height), fmt.Sprintf("%.0f TPS", sys.TPS), ctx)
 }
 
-func (c *Controls) drawButton(b *button, text string, win *opengl.Window) {
+func (c *Controls) drawButton(b *button, text string, ctx *window.Context) {
 	dr := &c.drawer
 
-	dr.Color = color.Black
+	dr.Color = ctx.Theme.Background
 	dr.Push(px.V(b.X, b.Y), px.V(b.X+b.W, b.Y+b.H))
 	dr.Rectangle(0)
 	dr.Reset()
 
-	dr.Color = color.White
+	dr.Color = ctx.Theme.Foreground
 	dr.Push(px.V(b.X, b.Y), px.V(b.X+b.W, b.Y+b.H))
 	dr.Rectangle(1)
 	dr.Reset()
 
-	dr.Draw(win)
+	dr.Draw(ctx)
 	dr.Clear()
 
 	c.text.Clear()
@@ -117,7 +146,7 @@ func (c *Controls) drawButton(b *button, text string, win *opengl.Window) {
 	wTxt := c.text.Bounds().W()
 	hTxt := c.text.Bounds().H()
 	cx, cy := b.Center()
-	c.text.Draw(win, px.IM.Scaled(px.V(wTxt/2, hTxt/2), c.Scale).Moved(px.V(math.Floor(cx-wTxt/2), math.Floor(cy-hTxt/2))))
+	c.text.Draw(ctx, px.IM.Scaled(px.V(wTxt/2, hTxt/2), c.Scale).Moved(px.V(math.Floor(cx-wTxt/2), math.Floor(cy-hTxt/2))))
 }
 
 func (c *Controls) pauseBounds(w, h float64) *button {
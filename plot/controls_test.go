@@ -1,10 +1,14 @@
 package plot_test
 
 import (
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
 	"github.com/mlange-42/arche-model/model"
 	"github.com/mlange-42/arche-model/system"
 	"github.com/mlange-42/arche-pixel/plot"
 	"github.com/mlange-42/arche-pixel/window"
+	"github.com/stretchr/testify/assert"
 )
 
 func ExampleControls() {
@@ -40,3 +44,86 @@ func ExampleControls() {
 
 	// Output:
 }
+
+func TestControls_Inputs(t *testing.T) {
+	m := model.New()
+	m.TPS = 30
+
+	input := keyInput{}
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), &input)
+
+	ctrl := plot.Controls{}
+	ctrl.Initialize(&m.World, ctx)
+
+	input.key = px.KeySpace
+	ctrl.UpdateInputs(&m.World, ctx)
+	assert.True(t, m.Systems.Paused)
+
+	input.key = px.KeyRight
+	ctrl.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, 40.0, m.Systems.TPS)
+
+	input.key = px.MouseButton1
+	input.mouse = px.V(750, 30)
+	ctrl.UpdateInputs(&m.World, ctx)
+	assert.False(t, m.Systems.Paused)
+}
+
+// keyInput is an input source for testing, reporting a single button as just pressed.
+type keyInput struct {
+	key   px.Button
+	mouse px.Vec
+}
+
+func (i *keyInput) Pressed(button px.Button) bool      { return button == i.key }
+func (i *keyInput) JustPressed(button px.Button) bool  { return button == i.key }
+func (i *keyInput) JustReleased(button px.Button) bool { return false }
+func (i *keyInput) Repeated(button px.Button) bool     { return false }
+func (i *keyInput) MousePosition() px.Vec              { return i.mouse }
+func (i *keyInput) MouseScroll() px.Vec                { return px.Vec{} }
+func (i *keyInput) Typed() string                      { return "" }
+
+func TestControls_Bindings(t *testing.T) {
+	m := model.New()
+	m.TPS = 30
+
+	input := keyInput{}
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), &input)
+	ctx.Bindings.Set("controls.faster", px.KeyUp)
+
+	ctrl := plot.Controls{}
+	ctrl.Initialize(&m.World, ctx)
+
+	input.key = px.KeyRight
+	ctrl.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, 30.0, m.Systems.TPS)
+
+	input.key = px.KeyUp
+	ctrl.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, 40.0, m.Systems.TPS)
+}
+
+func TestControls_Hit(t *testing.T) {
+	m := model.New()
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), nil)
+
+	ctrl := plot.Controls{}
+	ctrl.Initialize(&m.World, ctx)
+
+	assert.True(t, ctrl.Hit(ctx, px.V(750, 30)))
+	assert.True(t, ctrl.Hit(ctx, px.V(785, 12)))
+	assert.False(t, ctrl.Hit(ctx, px.V(400, 300)))
+}
+
+func TestControls_Shortcuts(t *testing.T) {
+	m := model.New()
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), nil)
+	ctx.Bindings.Set("controls.pause", px.KeyP)
+
+	ctrl := plot.Controls{}
+	ctrl.Initialize(&m.World, ctx)
+
+	shortcuts := ctrl.Shortcuts()
+	assert.Equal(t, 4, len(shortcuts))
+	assert.Equal(t, window.Shortcut{Keys: "P", Description: "Pause or resume the simulation"}, shortcuts[0])
+}
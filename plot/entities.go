@@ -0,0 +1,165 @@
+package plot
+
+import (
+	"image/color"
+	"math"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+)
+
+// Shape of entities drawn by [Entities].
+type Shape uint8
+
+const (
+	// ShapeCircle draws entities as filled circles. Circles ignore the heading.
+	ShapeCircle Shape = iota
+	// ShapeTriangle draws entities as triangles pointing in the direction of their heading.
+	ShapeTriangle
+	// ShapeSprite draws entities as sprites, rotated by their heading.
+	// Sprites are taken from a single sprite, or per entity from the frames of an [Atlas].
+	ShapeSprite
+)
+
+// Entities drawer for drawing all entities with a position component as shapes or sprites.
+//
+// Entities are considered if they have a component of type P, and their position is obtained via the Position function.
+// Positions and sizes are interpreted in drawing coordinates.
+// To map world coordinates to the screen, use the drawer in a [window.Camera].
+//
+// Heading, size, color and sprite frame are optional, and are usually derived from components using [FromComponent].
+// Entities without a value use the defaults.
+// For animations, the frame can be selected from an [Animation], e.g. based on a step counter component.
+// The heading is an angle in radians, counter-clockwise from the positive x axis.
+//
+// All entities are drawn in a single batch, omitting entities outside of the visible area.
+type Entities[P any] struct {
+	Position     func(p *P) px.Vec      // Function to get the position from a component. Required.
+	Heading      Attribute[float64]     // Heading of entities, in radians. Optional, default 0.
+	Size         Attribute[float64]     // Size of entities, as radius in drawing units. Optional, default DefaultSize.
+	Color        Attribute[color.Color] // Color of entities. Optional, default DefaultColor.
+	Shape        Shape                  // Shape for drawing entities. Optional, default ShapeCircle.
+	Sprite       *px.Sprite             // Sprite for drawing entities, pointing along the positive x axis. Required for ShapeSprite if Atlas is not set.
+	Atlas        *Atlas                 // Atlas with sprite frames for drawing entities. Optional, takes precedence over Sprite.
+	Frame        Attribute[string]      // Frame names in the Atlas. Optional, default DefaultFrame.
+	DefaultFrame string                 // Default frame name in the Atlas. Optional, default the atlas' first frame.
+	DefaultSize  float64                // Default size of entities. Optional, default 5.
+	DefaultColor color.Color            // Default color of entities. Optional, default the theme's first series color for shapes, and no tint for sprites.
+	filter       generic.Filter1[P]
+	drawer       imdraw.IMDraw
+	batch        *px.Batch
+}
+
+// Initialize the drawer.
+func (e *Entities[P]) Initialize(w *ecs.World, ctx *window.Context) {
+	if e.Position == nil {
+		panic("entities drawer requires a Position function")
+	}
+	if e.Shape == ShapeSprite && e.Sprite == nil && e.Atlas == nil {
+		panic("entities drawer requires a Sprite or an Atlas for ShapeSprite")
+	}
+	if e.Atlas != nil && e.DefaultFrame == "" {
+		if len(e.Atlas.Names()) == 0 {
+			panic("entities drawer requires an Atlas with at least one frame")
+		}
+		e.DefaultFrame = e.Atlas.Names()[0]
+	}
+	if e.DefaultSize <= 0 {
+		e.DefaultSize = 5
+	}
+	if e.DefaultColor == nil {
+		if e.Shape == ShapeSprite {
+			e.DefaultColor = color.White
+		} else {
+			e.DefaultColor = ctx.Theme.SeriesColor(0)
+		}
+	}
+
+	for _, attr := range []interface{ Initialize(*ecs.World) }{e.Heading, e.Size, e.Color, e.Frame} {
+		if attr != nil {
+			attr.Initialize(w)
+		}
+	}
+
+	e.filter = *generic.NewFilter1[P]()
+	e.drawer = *imdraw.New(nil)
+	if e.Shape == ShapeSprite {
+		if e.Atlas != nil {
+			e.batch = px.NewBatch(&px.TrianglesData{}, e.Atlas.Picture())
+		} else {
+			e.batch = px.NewBatch(&px.TrianglesData{}, e.Sprite.Picture())
+		}
+	}
+}
+
+// Update the drawer.
+func (e *Entities[P]) Update(w *ecs.World) {}
+
+// UpdateInputs handles input events of the previous frame update.
+func (e *Entities[P]) UpdateInputs(w *ecs.World, ctx *window.Context) {}
+
+// Draw the drawer.
+func (e *Entities[P]) Draw(w *ecs.World, ctx *window.Context) {
+	visible := visibleRect(ctx)
+
+	if e.Shape == ShapeSprite {
+		e.batch.Clear()
+	}
+	dr := &e.drawer
+
+	query := e.filter.Query(w)
+	for query.Next() {
+		entity := query.Entity()
+		pos := e.Position(query.Get())
+		size := attributeOr(e.Size, entity, e.DefaultSize)
+		if pos.X+size < visible.Min.X || pos.X-size > visible.Max.X ||
+			pos.Y+size < visible.Min.Y || pos.Y-size > visible.Max.Y {
+			continue
+		}
+		heading := attributeOr(e.Heading, entity, 0)
+		col := attributeOr(e.Color, entity, e.DefaultColor)
+
+		switch e.Shape {
+		case ShapeCircle:
+			dr.Color = col
+			dr.Push(pos)
+			dr.Circle(size, 0)
+		case ShapeTriangle:
+			dr.Color = col
+			dr.Push(
+				pos.Add(px.Unit(heading).Scaled(size)),
+				pos.Add(px.Unit(heading+0.8*math.Pi).Scaled(size)),
+				pos.Add(px.Unit(heading-0.8*math.Pi).Scaled(size)),
+			)
+			dr.Polygon(0)
+		case ShapeSprite:
+			sprite := e.Sprite
+			if e.Atlas != nil {
+				sprite = e.Atlas.Sprite(attributeOr(e.Frame, entity, e.DefaultFrame))
+			}
+			mat := px.IM.Scaled(px.ZV, 2*size/spriteSize(sprite)).Rotated(px.ZV, heading).Moved(pos)
+			sprite.DrawColorMask(e.batch, mat, col)
+		}
+	}
+
+	if e.Shape == ShapeSprite {
+		e.batch.Draw(ctx)
+		return
+	}
+	dr.Draw(ctx)
+	dr.Clear()
+}
+
+// attributeOr returns the value of an optional attribute for an entity, or the given default value.
+func attributeOr[V any](attr Attribute[V], e ecs.Entity, def V) V {
+	if attr == nil {
+		return def
+	}
+	if v, ok := attr.Get(e); ok {
+		return v
+	}
+	return def
+}
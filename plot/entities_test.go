@@ -0,0 +1,146 @@
+package plot_test
+
+import (
+	"image/color"
+	"math/rand"
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/plot"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/generic"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/colornames"
+)
+
+func ExampleEntities() {
+	// Create a new model.
+	m := model.New()
+
+	// Limit the the simulation speed.
+	m.TPS = 30
+
+	// Create some entities.
+	builder := generic.NewMap2[Position, Rotation](&m.World)
+	for i := 0; i < 100; i++ {
+		builder.NewWith(
+			&Position{X: rand.Float64() * 600, Y: rand.Float64() * 400},
+			&Rotation{Angle: rand.Float64() * 6.28},
+		)
+	}
+
+	// Create a drawer for entities with a Position component,
+	// with the heading taken from the Rotation component.
+	entities := plot.Entities[Position]{
+		Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+		Heading:  plot.FromComponent(func(r *Rotation) float64 { return r.Angle }),
+		Shape:    plot.ShapeTriangle,
+	}
+
+	// Add the drawer to a camera, for panning and zooming.
+	m.AddUISystem((&window.Window{}).
+		With((&window.Camera{}).With(&entities)))
+
+	// Add a termination system that ends the simulation.
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+
+	// Run the simulation.
+	// Due to the use of the OpenGL UI system, the model must be run via [window.Run].
+	// Comment out the code line above, and uncomment the next line to run this example stand-alone.
+
+	// window.Run(m)
+
+	// Output:
+}
+
+func TestEntities(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+
+	builder := generic.NewMap2[Position, Rotation](&m.World)
+	for i := 0; i < 100; i++ {
+		builder.NewWith(
+			&Position{X: rand.Float64()*1000 - 100, Y: rand.Float64()*800 - 100},
+			&Rotation{Angle: rand.Float64() * 6.28},
+		)
+	}
+	posBuilder := generic.NewMap1[Position](&m.World)
+	posBuilder.NewWith(&Position{X: 100, Y: 100})
+
+	picture := px.MakePictureData(px.R(0, 0, 16, 8))
+	for i := range picture.Pix {
+		picture.Pix[i] = color.RGBA{255, 255, 255, 255}
+	}
+
+	position := func(p *Position) px.Vec { return px.V(p.X, p.Y) }
+	m.AddUISystem((&window.Window{}).
+		With(
+			&plot.Entities[Position]{
+				Position: position,
+			},
+			&plot.Entities[Position]{
+				Position: position,
+				Heading:  plot.FromComponent(func(r *Rotation) float64 { return r.Angle }),
+				Size:     plot.FromComponent(func(r *Rotation) float64 { return 2 + r.Angle }),
+				Color:    plot.FromComponent(func(r *Rotation) color.Color { return colornames.Red }),
+				Shape:    plot.ShapeTriangle,
+			},
+			&plot.Entities[Position]{
+				Position: position,
+				Heading:  plot.FromComponent(func(r *Rotation) float64 { return r.Angle }),
+				Shape:    plot.ShapeSprite,
+				Sprite:   px.NewSprite(picture, picture.Bounds()),
+			},
+		))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+	m.Run()
+}
+
+func TestEntities_Panic(t *testing.T) {
+	m := model.New()
+	m.AddUISystem((&window.Window{}).
+		With(&plot.Entities[Position]{}))
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	assert.Panics(t, m.Run)
+
+	m = model.New()
+	m.AddUISystem((&window.Window{}).
+		With(&plot.Entities[Position]{
+			Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+			Shape:    plot.ShapeSprite,
+		}))
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	assert.Panics(t, m.Run)
+}
+
+func TestFromComponent(t *testing.T) {
+	m := model.New()
+	rotBuilder := generic.NewMap1[Rotation](&m.World)
+	posBuilder := generic.NewMap1[Position](&m.World)
+	e1 := rotBuilder.NewWith(&Rotation{Angle: 1.5})
+	e2 := posBuilder.New()
+
+	attr := plot.FromComponent(func(r *Rotation) float64 { return r.Angle })
+	attr.Initialize(&m.World)
+
+	v, ok := attr.Get(e1)
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, v)
+
+	v, ok = attr.Get(e2)
+	assert.False(t, ok)
+	assert.Equal(t, 0.0, v)
+}
@@ -0,0 +1,80 @@
+package plot
+
+import (
+	"fmt"
+	"image/color"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/text"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+)
+
+// EntityLabels drawer for showing text labels next to entities.
+//
+// Entities are considered if they have a component of type P, and their position is obtained via the Position function.
+// Positions are interpreted in drawing coordinates, like for [Entities].
+// Labels are drawn in screen pixels, i.e. they are not scaled when zooming a [window.Camera].
+//
+// Label texts are usually derived from a name component using [FromComponent], or from a formatter function using [FromFunc].
+// Entities without a label text are not labelled.
+// All labels are drawn in a single batch, omitting entities outside of the visible area.
+type EntityLabels[P any] struct {
+	Position func(p *P) px.Vec // Function to get the position from a component. Required.
+	Label    Attribute[string] // Label texts. Optional, default the entity ID.
+	Offset   px.Vec            // Offset of labels from entity positions, in pixels. Optional, default (8, 4).
+	Color    color.Color       // Text color. Optional, default the theme's foreground color.
+	Font     *text.Atlas       // Font for text. Optional, default the window's font.
+	filter   generic.Filter1[P]
+	text     *text.Text
+}
+
+// Initialize the drawer.
+func (l *EntityLabels[P]) Initialize(w *ecs.World, ctx *window.Context) {
+	if l.Position == nil {
+		panic("entity labels drawer requires a Position function")
+	}
+	if l.Label == nil {
+		l.Label = FromFunc(func(e ecs.Entity) string { return fmt.Sprint(e.ID()) })
+	}
+	if l.Offset == px.ZV {
+		l.Offset = px.V(8, 4)
+	}
+	if l.Color == nil {
+		l.Color = ctx.Theme.Foreground
+	}
+	l.Label.Initialize(w)
+
+	l.filter = *generic.NewFilter1[P]()
+	l.text = text.New(px.V(0, 0), fontOr(l.Font, ctx))
+}
+
+// Update the drawer.
+func (l *EntityLabels[P]) Update(w *ecs.World) {}
+
+// UpdateInputs handles input events of the previous frame update.
+func (l *EntityLabels[P]) UpdateInputs(w *ecs.World, ctx *window.Context) {}
+
+// Draw the drawer.
+func (l *EntityLabels[P]) Draw(w *ecs.World, ctx *window.Context) {
+	l.text.Clear()
+	l.text.Color = l.Color
+
+	query := l.filter.Query(w)
+	for query.Next() {
+		label, ok := l.Label.Get(query.Entity())
+		if !ok || label == "" {
+			continue
+		}
+		pos := ctx.Matrix.Project(l.Position(query.Get())).Add(l.Offset)
+		if !ctx.Bounds.Contains(pos) {
+			continue
+		}
+		l.text.Orig = pos
+		l.text.Dot = pos
+		fmt.Fprint(l.text, label)
+	}
+
+	l.text.Draw(ctx, invertMatrix(ctx.Matrix))
+}
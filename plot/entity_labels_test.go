@@ -0,0 +1,117 @@
+package plot_test
+
+import (
+	"fmt"
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/plot"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleEntityLabels() {
+	// Create a new model.
+	m := model.New()
+
+	// Limit the the simulation speed.
+	m.TPS = 30
+
+	// Create some entities with names.
+	builder := generic.NewMap2[Position, Name](&m.World)
+	builder.NewWith(&Position{X: 100, Y: 100}, &Name{Name: "Alice"})
+	builder.NewWith(&Position{X: 200, Y: 150}, &Name{Name: "Bob"})
+
+	// Draw entities, labelled with their names, and with a tooltip on mouse hover.
+	position := func(p *Position) px.Vec { return px.V(p.X, p.Y) }
+	m.AddUISystem((&window.Window{}).
+		With((&window.Camera{}).With(
+			&plot.Entities[Position]{
+				Position: position,
+			},
+			&plot.EntityLabels[Position]{
+				Position: position,
+				Label:    plot.FromComponent(func(n *Name) string { return n.Name }),
+			},
+			&plot.Tooltip[Position]{
+				Position: position,
+			},
+		)))
+
+	// Add a termination system that ends the simulation.
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+
+	// Run the simulation.
+	// Due to the use of the OpenGL UI system, the model must be run via [window.Run].
+	// Comment out the code line above, and uncomment the next line to run this example stand-alone.
+
+	// window.Run(m)
+
+	// Output:
+}
+
+func TestEntityLabels(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+
+	builder := generic.NewMap2[Position, Name](&m.World)
+	builder.NewWith(&Position{X: 100, Y: 100}, &Name{Name: "Alice"})
+	builder.NewWith(&Position{X: -100, Y: 100}, &Name{Name: "Bob"})
+	posBuilder := generic.NewMap1[Position](&m.World)
+	posBuilder.NewWith(&Position{X: 200, Y: 100})
+
+	position := func(p *Position) px.Vec { return px.V(p.X, p.Y) }
+	m.AddUISystem((&window.Window{}).
+		With(
+			&plot.EntityLabels[Position]{
+				Position: position,
+			},
+			&plot.EntityLabels[Position]{
+				Position: position,
+				Label:    plot.FromComponent(func(n *Name) string { return n.Name }),
+			},
+			&plot.EntityLabels[Position]{
+				Position: position,
+				Label:    plot.FromFunc(func(e ecs.Entity) string { return fmt.Sprintf("#%d", e.ID()) }),
+			},
+		))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+	m.Run()
+
+	m = model.New()
+	m.AddUISystem((&window.Window{}).
+		With(&plot.EntityLabels[Position]{}))
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	assert.Panics(t, m.Run)
+}
+
+func TestFromFunc(t *testing.T) {
+	m := model.New()
+	posBuilder := generic.NewMap1[Position](&m.World)
+	e := posBuilder.New()
+
+	attr := plot.FromFunc(func(e ecs.Entity) string { return fmt.Sprintf("#%d", e.ID()) })
+	attr.Initialize(&m.World)
+
+	v, ok := attr.Get(e)
+	assert.True(t, ok)
+	assert.Equal(t, fmt.Sprintf("#%d", e.ID()), v)
+}
+
+// Name component for labels.
+type Name struct {
+	Name string
+}
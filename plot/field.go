@@ -2,17 +2,12 @@ package plot
 
 import (
 	"fmt"
-	"image/color"
 
-	pixel "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/mlange-42/arche-model/observer"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
-	"gonum.org/v1/plot/vg"
-	"gonum.org/v1/plot/vg/draw"
-	"gonum.org/v1/plot/vg/vgimg"
 )
 
 // Field plot drawer.
@@ -30,7 +25,7 @@ type Field struct {
 }
 
 // Initialize the drawer.
-func (f *Field) Initialize(w *ecs.World, win *opengl.Window) {
+func (f *Field) Initialize(w *ecs.World, ctx *window.Context) {
 	f.Observer.Initialize(w)
 
 	f.data = plotField{
@@ -58,34 +53,31 @@ func (f *Field) Update(w *ecs.World) {
 }
 
 // UpdateInputs handles input events of the previous frame update.
-func (f *Field) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (f *Field) UpdateInputs(w *ecs.World, ctx *window.Context) {}
 
 // Draw the drawer.
-func (f *Field) Draw(w *ecs.World, win *opengl.Window) {
-	width := win.Canvas().Bounds().W()
-	height := win.Canvas().Bounds().H()
-
+func (f *Field) Draw(w *ecs.World, ctx *window.Context) {
 	f.updateData(w)
+	renderPlot(f.buildPlot(ctx), ctx, f.scale)
+}
 
-	canvas := vgimg.New(vg.Points(width*f.scale)-10, vg.Points(height*f.scale)-10)
+// ExportVector writes the plot to a vector graphics file.
+func (f *Field) ExportVector(w *ecs.World, ctx *window.Context, path string) error {
+	return savePlot(f.buildPlot(ctx), ctx, f.scale, path)
+}
 
-	p := plot.New()
-	setLabels(p, f.Labels)
+// buildPlot creates the plot from the current data.
+func (f *Field) buildPlot(ctx *window.Context) *plot.Plot {
+	p := newPlot(ctx, f.Labels)
 
 	p.X.Tick.Marker = removeLastTicks{}
 
 	field := plotter.NewField(&f.data)
+	field.LineStyle.Color = ctx.Theme.Foreground
 
 	p.Add(field)
 
-	win.Clear(color.White)
-	p.Draw(draw.New(canvas))
-
-	img := canvas.Image()
-	picture := pixel.PictureDataFromImage(img)
-
-	sprite := pixel.NewSprite(picture, picture.Bounds())
-	sprite.Draw(win, pixel.IM.Moved(pixel.V(picture.Rect.W()/2.0+5, picture.Rect.H()/2.0+5)))
+	return p
 }
 
 func (f *Field) updateData(w *ecs.World) {
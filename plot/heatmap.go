@@ -1,18 +1,12 @@
 package plot
 
 import (
-	"image/color"
-
-	pixel "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/mlange-42/arche-model/observer"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/palette"
 	"gonum.org/v1/plot/plotter"
-	"gonum.org/v1/plot/vg"
-	"gonum.org/v1/plot/vg/draw"
-	"gonum.org/v1/plot/vg/vgimg"
 )
 
 // HeatMap plot drawer.
@@ -32,7 +26,7 @@ type HeatMap struct {
 }
 
 // Initialize the drawer.
-func (h *HeatMap) Initialize(w *ecs.World, win *opengl.Window) {
+func (h *HeatMap) Initialize(w *ecs.World, ctx *window.Context) {
 	h.Observer.Initialize(w)
 	h.data = plotGrid{
 		Grid: h.Observer,
@@ -51,19 +45,22 @@ func (h *HeatMap) Update(w *ecs.World) {
 }
 
 // UpdateInputs handles input events of the previous frame update.
-func (h *HeatMap) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (h *HeatMap) UpdateInputs(w *ecs.World, ctx *window.Context) {}
 
 // Draw the drawer.
-func (h *HeatMap) Draw(w *ecs.World, win *opengl.Window) {
-	width := win.Canvas().Bounds().W()
-	height := win.Canvas().Bounds().H()
-
+func (h *HeatMap) Draw(w *ecs.World, ctx *window.Context) {
 	h.updateData(w)
+	renderPlot(h.buildPlot(ctx), ctx, h.scale)
+}
 
-	c := vgimg.New(vg.Points(width*h.scale)-10, vg.Points(height*h.scale)-10)
+// ExportVector writes the plot to a vector graphics file.
+func (h *HeatMap) ExportVector(w *ecs.World, ctx *window.Context, path string) error {
+	return savePlot(h.buildPlot(ctx), ctx, h.scale, path)
+}
 
-	p := plot.New()
-	setLabels(p, h.Labels)
+// buildPlot creates the plot from the current data.
+func (h *HeatMap) buildPlot(ctx *window.Context) *plot.Plot {
+	p := newPlot(ctx, h.Labels)
 
 	p.X.Tick.Marker = removeLastTicks{}
 
@@ -80,14 +77,7 @@ func (h *HeatMap) Draw(w *ecs.World, win *opengl.Window) {
 
 	p.Add(&heat)
 
-	win.Clear(color.White)
-	p.Draw(draw.New(c))
-
-	img := c.Image()
-	picture := pixel.PictureDataFromImage(img)
-
-	sprite := pixel.NewSprite(picture, picture.Bounds())
-	sprite.Draw(win, pixel.IM.Moved(pixel.V(picture.Rect.W()/2.0+5, picture.Rect.H()/2.0+5)))
+	return p
 }
 
 func (h *HeatMap) updateData(w *ecs.World) {
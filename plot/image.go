@@ -1,10 +1,12 @@
 package plot
 
 import (
+	"fmt"
 	"image/color"
+	"math"
 
 	pixel "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
+	"github.com/gopxl/pixel/v2/ext/text"
 	"github.com/mazznoer/colorgrad"
 	"github.com/mlange-42/arche-model/observer"
 	"github.com/mlange-42/arche-pixel/window"
@@ -16,28 +18,76 @@ import (
 // Draws an image from a Matrix observer.
 // The image is scaled to the canvas extent, with preserved aspect ratio.
 // Does not add plot axes etc.
+//
+// By default, values are mapped linearly from the fixed range Min to Max.
+// For automatic ranges, see [ImageRange], and for further mappings see [ImageNorm].
+// NaN and infinite values are drawn in the NoData color.
+//
+// When the mouse is over the image, the column and row of the cell under the cursor are shown, together with its value.
 type Image struct {
-	Scale    float64            // Spatial scaling: cell size in screen pixels. Optional, default auto.
-	Observer observer.Matrix    // Observer providing 2D matrix or grid data.
-	Colors   colorgrad.Gradient // Colors for mapping values.
-	Min      float64            // Minimum value for color mapping. Optional.
-	Max      float64            // Maximum value for color mapping. Optional. Is set to 1.0 if both Min and Max are zero.
-	slope    float64
-	picture  *pixel.PictureData
+	Scale       float64            // Spatial scaling: cell size in screen pixels. Optional, default auto.
+	Observer    observer.Matrix    // Observer providing 2D matrix or grid data.
+	Colors      colorgrad.Gradient // Colors for mapping values.
+	Min         float64            // Minimum value for color mapping. Optional.
+	Max         float64            // Maximum value for color mapping. Optional. Is set to 1.0 if both Min and Max are zero.
+	Range       ImageRange         // Mode for determining the value range. Optional, default fixed range from Min to Max.
+	Percentile  float64            // Percentile for clipping with [ImageRangePercentile], in percent. Optional, default 2, i.e. the 2nd to 98th percentile.
+	Norm        ImageNorm          // Normalization of values. Optional, default linear.
+	Center      float64            // Center value for [ImageNormDiverging]. Optional, default 0.
+	NoData      color.Color        // Color for NaN, infinite and other invalid values. Optional, default transparent.
+	ColorBar    *ColorBar          // Color bar legend, drawn next to the image. Optional, default none.
+	HideReadout bool               // Hides the readout of the cell under the mouse cursor. Optional, default false.
+	Font        *text.Atlas        // Font for the readout. Optional, default the window's font.
+	min         float64
+	max         float64
+	offset      float64
+	slope       float64
+	noData      color.RGBA
+	hasRange    bool
+	sorted      []float64
+	picture     *pixel.PictureData
+	values      []float64
+	readout     imageReadout
 }
 
 // Initialize the system
-func (i *Image) Initialize(w *ecs.World, win *opengl.Window) {
+func (i *Image) Initialize(w *ecs.World, ctx *window.Context) {
 	i.Observer.Initialize(w)
 
 	if i.Min == 0 && i.Max == 0 {
 		i.Max = 1
 	}
+	if i.Percentile == 0 {
+		i.Percentile = 2
+	}
+	if i.Percentile < 0 || i.Percentile >= 50 {
+		panic("image plot Percentile must be in range [0, 50)")
+	}
+	if i.Range == ImageRangeFixed && i.Norm == ImageNormLog && (i.Min <= 0 || i.Max <= 0) {
+		panic("image plot with logarithmic normalization requires positive Min and Max")
+	}
+
+	i.noData = color.RGBA{}
+	if i.NoData != nil {
+		i.noData = color.RGBAModel.Convert(i.NoData).(color.RGBA)
+	}
 
-	i.slope = 1.0 / (i.Max - i.Min)
+	min, max := i.Min, i.Max
+	if i.Norm == ImageNormLog && (min <= 0 || max <= 0) {
+		// Preliminary range for automatic ranges, until there are positive values.
+		min, max = 1, 10
+	}
+	i.hasRange = false
+	i.setRange(min, max)
 
 	width, height := i.Observer.Dims()
 	i.picture = pixel.MakePictureData(pixel.R(0, 0, float64(width), float64(height)))
+
+	if i.ColorBar != nil {
+		i.ColorBar.initialize(ctx)
+	}
+	i.readout = newImageReadout(ctx, i.Font, width, height)
+	i.values = nil
 }
 
 // Update the drawer.
@@ -46,31 +96,84 @@ func (i *Image) Update(w *ecs.World) {
 }
 
 // UpdateInputs handles input events of the previous frame update.
-func (i *Image) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (i *Image) UpdateInputs(w *ecs.World, ctx *window.Context) {
+	if i.HideReadout {
+		return
+	}
+	i.readout.updateInputs(ctx)
+}
 
 // Draw the system
-func (i *Image) Draw(w *ecs.World, win *opengl.Window) {
+func (i *Image) Draw(w *ecs.World, ctx *window.Context) {
 	values := i.Observer.Values(w)
+	i.values = values
+	i.updateRange(values)
 
 	length := len(values)
 	for j := 0; j < length; j++ {
 		i.picture.Pix[j] = i.valueToColor(values[j])
 	}
 
-	scale := i.Scale
-	if i.Scale <= 0 {
-		scale = window.Scale(win, i.picture.Rect.W(), i.picture.Rect.H())
+	var channels []colorBarChannel
+	if i.ColorBar != nil {
+		channels = []colorBarChannel{{Min: i.min, Max: i.max, Log: i.Norm == ImageNormLog, Color: i.valueToColor}}
 	}
+	i.readout.rect = drawPicture(ctx, i.picture, i.Scale, i.ColorBar, channels)
 
-	sprite := pixel.NewSprite(i.picture, i.picture.Bounds())
-	sprite.Draw(win,
-		pixel.IM.Moved(pixel.V(i.picture.Rect.W()/2.0, i.picture.Rect.H()/2.0)).
-			Scaled(pixel.Vec{}, scale),
+	if !i.HideReadout && i.readout.hovered {
+		r := &i.readout
+		r.draw(ctx, fmt.Sprintf("x %d, y %d\n%.4g", r.col, r.row, i.values[r.index()]))
+	}
+}
+
+// Hovered returns the column and row of the cell under the mouse cursor.
+// The last return value is false if the mouse is not over the image.
+func (i *Image) Hovered() (col, row int, ok bool) {
+	return i.readout.col, i.readout.row, i.readout.hovered
+}
+
+// drawPicture draws an image picture, scaled to the context's bounds if scale is not positive.
+// If a color bar is given, it is drawn next to the image, with the given channels.
+// Returns the rectangle covered by the image, in the context's drawing coordinates.
+// In a [window.Camera], these differ from pixel coordinates by the context's Matrix.
+func drawPicture(ctx *window.Context, picture *pixel.PictureData, scale float64, bar *ColorBar, channels []colorBarChannel) pixel.Rect {
+	bounds := ctx.Bounds
+	if bar != nil {
+		bounds = bar.imageBounds(bounds, channels)
+	}
+
+	width, height := picture.Rect.W(), picture.Rect.H()
+	if scale <= 0 {
+		scale = math.Min(bounds.W()/width, bounds.H()/height)
+	}
+
+	sprite := pixel.NewSprite(picture, picture.Bounds())
+	sprite.Draw(ctx,
+		pixel.IM.Moved(pixel.V(width/2.0, height/2.0)).
+			Scaled(pixel.Vec{}, scale).
+			Moved(bounds.Min),
 	)
+
+	rect := pixel.R(bounds.Min.X, bounds.Min.Y, bounds.Min.X+width*scale, bounds.Min.Y+height*scale)
+	if bar != nil {
+		bar.draw(ctx, rect, channels)
+	}
+	return rect
+}
+
+// Limits returns the current value range of the color mapping.
+func (i *Image) Limits() (min, max float64) {
+	return i.min, i.max
 }
 
 func (i *Image) valueToColor(v float64) color.RGBA {
-	c := i.Colors.At((v - i.Min) * i.slope)
+	if !i.isValid(v) {
+		return i.noData
+	}
+	if i.Norm == ImageNormLog {
+		v = math.Log(v)
+	}
+	c := i.Colors.At((v - i.offset) * i.slope)
 	return color.RGBA{
 		R: uint8(c.R * 255),
 		G: uint8(c.G * 255),
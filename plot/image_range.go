@@ -0,0 +1,105 @@
+package plot
+
+import (
+	"math"
+	"sort"
+)
+
+// ImageRange is the mode for determining the value range of an [Image]'s color mapping.
+type ImageRange uint8
+
+const (
+	ImageRangeFixed      ImageRange = iota // Fixed range from Min to Max.
+	ImageRangeFrame                        // Range of the values in the current frame.
+	ImageRangeExpanding                    // Range of all values seen so far.
+	ImageRangePercentile                   // Range between percentiles of the values in the current frame, clipping outliers.
+)
+
+// ImageNorm is the normalization of values for an [Image]'s color mapping.
+type ImageNorm uint8
+
+const (
+	ImageNormLinear    ImageNorm = iota // Linear mapping of values.
+	ImageNormLog                        // Logarithmic mapping of values. Values that are not positive are treated as no-data.
+	ImageNormDiverging                  // Linear mapping, with the range extended to be symmetric around Center.
+)
+
+// updateRange updates the value range from the values of the current frame, according to the range mode.
+func (i *Image) updateRange(values []float64) {
+	if i.Range == ImageRangeFixed {
+		return
+	}
+
+	i.sorted = i.sorted[:0]
+	for _, v := range values {
+		if i.isValid(v) {
+			i.sorted = append(i.sorted, v)
+		}
+	}
+	if len(i.sorted) == 0 {
+		return
+	}
+
+	var min, max float64
+	switch i.Range {
+	case ImageRangePercentile:
+		sort.Float64s(i.sorted)
+		min = percentile(i.sorted, i.Percentile)
+		max = percentile(i.sorted, 100-i.Percentile)
+	default:
+		min, max = i.sorted[0], i.sorted[0]
+		for _, v := range i.sorted[1:] {
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+		if i.Range == ImageRangeExpanding && i.hasRange {
+			min = math.Min(min, i.min)
+			max = math.Max(max, i.max)
+		}
+	}
+	i.setRange(min, max)
+	i.hasRange = true
+}
+
+// setRange sets the value range of the color mapping, and adjusts it to the normalization.
+func (i *Image) setRange(min, max float64) {
+	if i.Norm == ImageNormDiverging {
+		d := math.Max(math.Abs(min-i.Center), math.Abs(max-i.Center))
+		min, max = i.Center-d, i.Center+d
+	}
+	if min == max {
+		if i.Norm == ImageNormLog {
+			min, max = min/2, max*2
+		} else {
+			min, max = min-0.5, max+0.5
+		}
+	}
+	i.min, i.max = min, max
+
+	if i.Norm == ImageNormLog {
+		i.offset = math.Log(min)
+		i.slope = 1.0 / (math.Log(max) - i.offset)
+	} else {
+		i.offset = min
+		i.slope = 1.0 / (max - min)
+	}
+}
+
+// isValid returns whether a value can be mapped to a color.
+func (i *Image) isValid(v float64) bool {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return false
+	}
+	return i.Norm != ImageNormLog || v > 0
+}
+
+// percentile returns the given percentile of sorted values, with linear interpolation.
+func percentile(sorted []float64, p float64) float64 {
+	pos := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	frac := pos - float64(lower)
+	return sorted[lower]*(1-frac) + sorted[lower+1]*frac
+}
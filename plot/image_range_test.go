@@ -0,0 +1,91 @@
+package plot
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImage_Range(t *testing.T) {
+	values := []float64{3, 1, math.NaN(), 2, math.Inf(1), 5}
+
+	img := Image{Range: ImageRangeFrame}
+	img.setRange(0, 1)
+	img.updateRange(values)
+	min, max := img.Limits()
+	assert.Equal(t, 1.0, min)
+	assert.Equal(t, 5.0, max)
+
+	img.updateRange([]float64{2, 3})
+	min, max = img.Limits()
+	assert.Equal(t, 2.0, min)
+	assert.Equal(t, 3.0, max)
+
+	img.updateRange([]float64{math.NaN()})
+	min, max = img.Limits()
+	assert.Equal(t, 2.0, min)
+	assert.Equal(t, 3.0, max)
+
+	img = Image{Range: ImageRangeExpanding}
+	img.setRange(0, 1)
+	img.updateRange(values)
+	img.updateRange([]float64{2, 3, 7})
+	min, max = img.Limits()
+	assert.Equal(t, 1.0, min)
+	assert.Equal(t, 7.0, max)
+
+	img = Image{Range: ImageRangeFixed}
+	img.setRange(0, 1)
+	img.updateRange(values)
+	min, max = img.Limits()
+	assert.Equal(t, 0.0, min)
+	assert.Equal(t, 1.0, max)
+}
+
+func TestImage_RangePercentile(t *testing.T) {
+	values := make([]float64, 101)
+	for i := range values {
+		values[i] = float64(100 - i)
+	}
+	values[50] = math.NaN()
+
+	img := Image{Range: ImageRangePercentile, Percentile: 10}
+	img.updateRange(values)
+	min, max := img.Limits()
+	assert.InDelta(t, 9.9, min, 1e-9)
+	assert.InDelta(t, 90.1, max, 1e-9)
+
+	assert.Equal(t, 1.0, percentile([]float64{1, 2}, 0))
+	assert.Equal(t, 2.0, percentile([]float64{1, 2}, 100))
+	assert.Equal(t, 1.5, percentile([]float64{1, 2}, 50))
+}
+
+func TestImage_Norm(t *testing.T) {
+	img := Image{Norm: ImageNormDiverging, Center: 1}
+	img.setRange(-3, 2)
+	min, max := img.Limits()
+	assert.Equal(t, -3.0, min)
+	assert.Equal(t, 5.0, max)
+
+	img.setRange(1, 1)
+	min, max = img.Limits()
+	assert.Equal(t, 0.5, min)
+	assert.Equal(t, 1.5, max)
+
+	img = Image{Norm: ImageNormLog, Range: ImageRangeFrame}
+	img.setRange(1, 10)
+	img.updateRange([]float64{-1, 0, 10, 1000})
+	min, max = img.Limits()
+	assert.Equal(t, 10.0, min)
+	assert.Equal(t, 1000.0, max)
+	assert.InDelta(t, 0.5, (math.Log(100)-img.offset)*img.slope, 1e-9)
+
+	assert.False(t, img.isValid(0))
+	assert.False(t, img.isValid(math.NaN()))
+	assert.True(t, img.isValid(0.1))
+
+	img.noData = color.RGBA{R: 255, A: 255}
+	assert.Equal(t, img.noData, img.valueToColor(-1))
+}
@@ -0,0 +1,65 @@
+package plot
+
+import (
+	"math"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/gopxl/pixel/v2/ext/text"
+	"github.com/mlange-42/arche-pixel/window"
+)
+
+// imageReadout finds the image cell under the mouse cursor, and shows a readout of it.
+// Used by [Image] and [ImageRGB].
+type imageReadout struct {
+	width   int
+	height  int
+	rect    px.Rect
+	col     int
+	row     int
+	hovered bool
+	mouse   px.Vec
+	drawer  imdraw.IMDraw
+	text    *text.Text
+}
+
+// newImageReadout creates a readout for an image with the given number of columns and rows.
+func newImageReadout(ctx *window.Context, font *text.Atlas, width, height int) imageReadout {
+	return imageReadout{
+		width:  width,
+		height: height,
+		drawer: *imdraw.New(nil),
+		text:   text.New(px.V(0, 0), fontOr(font, ctx)),
+	}
+}
+
+// updateInputs finds the cell under the mouse cursor.
+// Uses the image rectangle of the previous draw.
+func (r *imageReadout) updateInputs(ctx *window.Context) {
+	pos := ctx.MousePosition()
+	r.mouse = ctx.Matrix.Project(pos)
+	r.hovered = false
+	if !ctx.Bounds.Contains(r.mouse) || r.rect.Area() == 0 {
+		return
+	}
+
+	col := int(math.Floor((pos.X - r.rect.Min.X) / r.rect.W() * float64(r.width)))
+	row := int(math.Floor((pos.Y - r.rect.Min.Y) / r.rect.H() * float64(r.height)))
+	if col < 0 || row < 0 || col >= r.width || row >= r.height {
+		return
+	}
+	r.col, r.row, r.hovered = col, row, true
+}
+
+// index returns the index of the hovered cell in the observer's data.
+func (r *imageReadout) index() int {
+	return r.row*r.width + r.col
+}
+
+// draw draws the readout text next to the mouse cursor, if a cell is hovered.
+func (r *imageReadout) draw(ctx *window.Context, readout string) {
+	r.text.Clear()
+	r.text.Color = ctx.Theme.Foreground
+	r.text.WriteString(readout)
+	drawTextBox(ctx, &r.drawer, r.text, r.mouse)
+}
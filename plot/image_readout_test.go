@@ -0,0 +1,77 @@
+package plot
+
+import (
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/stretchr/testify/assert"
+)
+
+type mouseInput struct {
+	mouse px.Vec
+}
+
+func (i *mouseInput) Pressed(button px.Button) bool      { return false }
+func (i *mouseInput) JustPressed(button px.Button) bool  { return false }
+func (i *mouseInput) JustReleased(button px.Button) bool { return false }
+func (i *mouseInput) Repeated(button px.Button) bool     { return false }
+func (i *mouseInput) MousePosition() px.Vec              { return i.mouse }
+func (i *mouseInput) MouseScroll() px.Vec                { return px.Vec{} }
+func (i *mouseInput) Typed() string                      { return "" }
+
+func TestImageReadout(t *testing.T) {
+	input := mouseInput{}
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), &input)
+
+	r := newImageReadout(ctx, nil, 40, 30)
+
+	input.mouse = px.V(10, 10)
+	r.updateInputs(ctx)
+	assert.False(t, r.hovered)
+
+	r.rect = px.R(100, 0, 500, 300)
+
+	input.mouse = px.V(125, 15)
+	r.updateInputs(ctx)
+	assert.True(t, r.hovered)
+	assert.Equal(t, 2, r.col)
+	assert.Equal(t, 1, r.row)
+	assert.Equal(t, 42, r.index())
+
+	input.mouse = px.V(499, 299)
+	r.updateInputs(ctx)
+	assert.True(t, r.hovered)
+	assert.Equal(t, 39, r.col)
+	assert.Equal(t, 29, r.row)
+
+	input.mouse = px.V(50, 15)
+	r.updateInputs(ctx)
+	assert.False(t, r.hovered)
+
+	input.mouse = px.V(200, 400)
+	r.updateInputs(ctx)
+	assert.False(t, r.hovered)
+}
+
+func TestImageRGB_ReadoutText(t *testing.T) {
+	input := mouseInput{}
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), &input)
+
+	img := ImageRGB{
+		Layers: []int{1, -1, 0},
+		Names:  []string{"Prey", "", "Predators"},
+		values: [][]float64{{0, 0.25, 0, 0}, {0, 0.5, 0, 0}},
+	}
+	img.readout = newImageReadout(ctx, nil, 2, 2)
+	img.readout.rect = px.R(0, 0, 200, 200)
+
+	input.mouse = px.V(150, 50)
+	img.UpdateInputs(nil, ctx)
+
+	col, row, ok := img.Hovered()
+	assert.True(t, ok)
+	assert.Equal(t, 1, col)
+	assert.Equal(t, 0, row)
+	assert.Equal(t, "x 1, y 0\nPrey: 0.5\nPredators: 0.25", img.readoutText())
+}
@@ -1,6 +1,7 @@
 package plot_test
 
 import (
+	"image/color"
 	"math"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/mlange-42/arche-pixel/plot"
 	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
+	"github.com/stretchr/testify/assert"
 )
 
 func ExampleImage() {
@@ -67,6 +69,103 @@ func TestImage_LimitsScale(t *testing.T) {
 	m.Run()
 }
 
+func TestImage_ColorBar(t *testing.T) {
+	positions := []plot.ColorBarPosition{
+		plot.ColorBarRight, plot.ColorBarLeft, plot.ColorBarBottom, plot.ColorBarTop,
+	}
+	for _, pos := range positions {
+		m := model.New()
+		m.TPS = 300
+		m.FPS = 0
+		m.AddUISystem(
+			(&window.Window{}).
+				With(&plot.Image{
+					Observer: &MatrixObserver{},
+					Colors:   colorgrad.Inferno(),
+					Min:      -2,
+					Max:      2,
+					ColorBar: &plot.ColorBar{
+						Position: pos,
+						Ticks:    3,
+						Label:    "Elevation",
+						Format:   "%.1f m",
+					},
+				}))
+
+		m.AddSystem(&system.FixedTermination{
+			Steps: 10,
+		})
+
+		m.Run()
+	}
+}
+
+func TestImage_ColorBarPanic(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+	m.AddUISystem(
+		(&window.Window{}).
+			With(&plot.Image{
+				Observer: &MatrixObserver{},
+				Colors:   colorgrad.Inferno(),
+				ColorBar: &plot.ColorBar{Ticks: 1},
+			}))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+
+	assert.Panics(t, m.Run)
+}
+
+func TestImage_Range(t *testing.T) {
+	images := []plot.Image{
+		{Range: plot.ImageRangeFrame, Norm: plot.ImageNormDiverging},
+		{Range: plot.ImageRangeExpanding},
+		{Range: plot.ImageRangePercentile, Percentile: 5, NoData: color.White},
+		{Range: plot.ImageRangeFrame, Norm: plot.ImageNormLog, ColorBar: &plot.ColorBar{}},
+		{Min: 0.01, Max: 2, Norm: plot.ImageNormLog, ColorBar: &plot.ColorBar{}},
+	}
+	for _, img := range images {
+		img := img
+		img.Observer = &MatrixObserver{}
+		img.Colors = colorgrad.RdBu()
+
+		m := model.New()
+		m.TPS = 300
+		m.FPS = 0
+		m.AddUISystem((&window.Window{}).With(&img))
+
+		m.AddSystem(&system.FixedTermination{
+			Steps: 10,
+		})
+
+		m.Run()
+	}
+}
+
+func TestImage_RangePanic(t *testing.T) {
+	images := []plot.Image{
+		{Norm: plot.ImageNormLog},
+		{Range: plot.ImageRangePercentile, Percentile: 50},
+	}
+	for _, img := range images {
+		img := img
+		img.Observer = &MatrixObserver{}
+		img.Colors = colorgrad.Inferno()
+
+		m := model.New()
+		m.TPS = 300
+		m.AddUISystem((&window.Window{}).With(&img))
+
+		m.AddSystem(&system.FixedTermination{
+			Steps: 10,
+		})
+
+		assert.Panics(t, m.Run)
+	}
+}
+
 // Example observer, reporting a matrix with z = sin(0.1*i) + sin(0.2*j).
 type MatrixObserver struct {
 	cols   int
@@ -6,9 +6,9 @@ import (
 	"reflect"
 
 	px "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/gopxl/pixel/v2/ext/text"
 	"github.com/mlange-42/arche-model/resource"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
 	"github.com/mlange-42/arche/generic"
 )
@@ -21,62 +21,71 @@ import (
 // Details can be adjusted using the HideXxx fields.
 // Further, keys F, T, V and N can be used to toggle details during a running simulation.
 // The view can be scrolled using arrow keys or the mouse wheel.
+// Default keys are shared with [Resources] and [Systems]. To use them in the same window,
+// put them into [window.Tabs], where only the selected tab receives user input, or remap keys.
+// Press F1 to show all shortcuts.
+// Keys can be remapped via the actions "inspector.fields", "inspector.scroll-up" etc. (see [window.Bindings]).
 type Inspector struct {
-	HideFields  bool // Hides components fields.
-	HideTypes   bool // Hides field types.
-	HideValues  bool // Hides field values.
-	HideNames   bool // Hide field names of nested structs.
+	HideFields  bool        // Hides components fields.
+	HideTypes   bool        // Hides field types.
+	HideValues  bool        // Hides field values.
+	HideNames   bool        // Hide field names of nested structs.
+	Font        *text.Atlas // Font for text. Optional, default the window's font.
 	scroll      int
 	selectedRes generic.Resource[resource.SelectedEntity]
 	text        *text.Text
-	helpText    *text.Text
+	keys        detailActions
 }
 
 // Initialize the system
-func (i *Inspector) Initialize(w *ecs.World, win *opengl.Window) {
+func (i *Inspector) Initialize(w *ecs.World, ctx *window.Context) {
 	i.selectedRes = generic.NewResource[resource.SelectedEntity](w)
 
-	i.text = text.New(px.V(0, 0), defaultFont)
-	i.helpText = text.New(px.V(0, 0), defaultFont)
+	i.text = text.New(px.V(0, 0), fontOr(i.Font, ctx))
 
 	i.text.AlignedTo(px.BottomRight)
-	i.helpText.AlignedTo(px.BottomRight)
+	i.text.Color = ctx.Theme.Foreground
 
-	fmt.Fprint(i.helpText, "Toggle [f]ields, [t]ypes, [v]alues or [n]ames, scroll with arrows or mouse wheel.")
+	i.keys = newDetailActions(ctx.Bindings, "inspector")
+}
+
+// Shortcuts returns the drawer's keyboard and mouse shortcuts.
+func (i *Inspector) Shortcuts() []window.Shortcut {
+	return i.keys.shortcuts()
 }
 
 // Update the drawer.
 func (i *Inspector) Update(w *ecs.World) {}
 
 // UpdateInputs handles input events of the previous frame update.
-func (i *Inspector) UpdateInputs(w *ecs.World, win *opengl.Window) {
-	if win.JustPressed(px.KeyF) {
+func (i *Inspector) UpdateInputs(w *ecs.World, ctx *window.Context) {
+	if ctx.JustPressed(i.keys.fields.Key) {
 		i.HideFields = !i.HideFields
 		return
 	}
-	if win.JustPressed(px.KeyT) {
+	if ctx.JustPressed(i.keys.types.Key) {
 		i.HideTypes = !i.HideTypes
 		return
 	}
-	if win.JustPressed(px.KeyV) {
+	if ctx.JustPressed(i.keys.values.Key) {
 		i.HideValues = !i.HideValues
 		return
 	}
-	if win.JustPressed(px.KeyN) {
+	if ctx.JustPressed(i.keys.names.Key) {
 		i.HideNames = !i.HideNames
 		return
 	}
-	if win.JustPressed(px.KeyDown) {
+	if ctx.JustPressed(i.keys.scrollDown.Key) {
 		i.scroll++
 		return
 	}
-	if win.JustPressed(px.KeyUp) {
+	if ctx.JustPressed(i.keys.scrollUp.Key) {
 		if i.scroll > 0 {
 			i.scroll--
 		}
 		return
 	}
-	scr := win.MouseScroll()
+	scr := ctx.MouseScroll()
 	if scr.Y != 0 {
 		i.scroll -= int(scr.Y)
 		if i.scroll < 0 {
@@ -86,9 +95,7 @@ func (i *Inspector) UpdateInputs(w *ecs.World, win *opengl.Window) {
 }
 
 // Draw the system
-func (i *Inspector) Draw(w *ecs.World, win *opengl.Window) {
-	i.helpText.Draw(win, px.IM.Moved(px.V(10, 20)))
-
+func (i *Inspector) Draw(w *ecs.World, ctx *window.Context) {
 	if !i.selectedRes.Has() {
 		return
 	}
@@ -97,7 +104,7 @@ func (i *Inspector) Draw(w *ecs.World, win *opengl.Window) {
 		return
 	}
 
-	height := win.Canvas().Bounds().H()
+	height := ctx.Bounds.H()
 	x0 := 10.0
 	y0 := height - 10.0
 
@@ -106,7 +113,7 @@ func (i *Inspector) Draw(w *ecs.World, win *opengl.Window) {
 
 	if !w.Alive(sel) {
 		fmt.Fprint(i.text, "  dead entity")
-		i.text.Draw(win, px.IM.Moved(px.V(x0, y0)))
+		i.text.Draw(ctx, px.IM.Moved(px.V(x0, y0)))
 		return
 	}
 
@@ -146,7 +153,7 @@ func (i *Inspector) Draw(w *ecs.World, win *opengl.Window) {
 		}
 	}
 
-	i.text.Draw(win, px.IM.Moved(px.V(x0, y0)))
+	i.text.Draw(ctx, px.IM.Moved(px.V(x0, y0)))
 }
 
 func (i *Inspector) printField(w io.Writer, tp reflect.Type, field reflect.StructField, value reflect.Value) {
@@ -91,3 +91,25 @@ func TestInspector_DeadEntity(t *testing.T) {
 
 	m.Run()
 }
+
+func TestInspector_Font(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+
+	posID := ecs.ComponentID[Position](&m.World)
+	entity := m.World.NewEntity(posID)
+
+	ecs.AddResource(&m.World, &resource.SelectedEntity{Selected: entity})
+
+	m.AddUISystem((&window.Window{Font: window.GoFont(16)}).
+		With(
+			&plot.Inspector{},
+			&plot.Inspector{Font: window.GoMonoFont(12)},
+		))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+}
@@ -5,15 +5,11 @@ import (
 	"image/color"
 	"math"
 
-	pixel "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/mlange-42/arche-model/observer"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
-	"gonum.org/v1/plot/vg"
-	"gonum.org/v1/plot/vg/draw"
-	"gonum.org/v1/plot/vg/vgimg"
 )
 
 // Lines plot drawer.
@@ -22,12 +18,14 @@ import (
 // Replaces the complete data by the table provided by the observer on every update.
 // Particularly useful for live histograms.
 type Lines struct {
-	Observer observer.Table // Observer providing a data series for lines.
-	X        string         // X column name. Optional. Defaults to row index.
-	Y        []string       // Y column names. Optional. Defaults to all but X column.
-	XLim     [2]float64     // X axis limits. Optional, default auto.
-	YLim     [2]float64     // Y axis limits. Optional, default auto.
-	Labels   Labels         // Labels for plot and axes. Optional.
+	Observer    observer.Table // Observer providing a data series for lines.
+	X           string         // X column name. Optional. Defaults to row index.
+	Y           []string       // Y column names. Optional. Defaults to all but X column.
+	XLim        [2]float64     // X axis limits. Optional, default auto.
+	YLim        [2]float64     // Y axis limits. Optional, default auto.
+	Labels      Labels         // Labels for plot and axes. Optional.
+	Palette     []color.Color  // Colors of the series. Optional, default the palette of the window's theme.
+	CycleStyles bool           // Vary the dash pattern with every series, for grayscale print. Optional, default only when colors repeat.
 
 	xIndex   int
 	yIndices []int
@@ -38,7 +36,7 @@ type Lines struct {
 }
 
 // Initialize the drawer.
-func (l *Lines) Initialize(w *ecs.World, win *opengl.Window) {
+func (l *Lines) Initialize(w *ecs.World, ctx *window.Context) {
 	l.Observer.Initialize(w)
 
 	l.headers = l.Observer.Header()
@@ -81,19 +79,22 @@ func (l *Lines) Update(w *ecs.World) {
 }
 
 // UpdateInputs handles input events of the previous frame update.
-func (l *Lines) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (l *Lines) UpdateInputs(w *ecs.World, ctx *window.Context) {}
 
 // Draw the drawer.
-func (l *Lines) Draw(w *ecs.World, win *opengl.Window) {
-	width := win.Canvas().Bounds().W()
-	height := win.Canvas().Bounds().H()
-
+func (l *Lines) Draw(w *ecs.World, ctx *window.Context) {
 	l.updateData(w)
+	renderPlot(l.buildPlot(ctx), ctx, l.scale)
+}
 
-	c := vgimg.New(vg.Points(width*l.scale)-10, vg.Points(height*l.scale)-10)
+// ExportVector writes the plot to a vector graphics file.
+func (l *Lines) ExportVector(w *ecs.World, ctx *window.Context, path string) error {
+	return savePlot(l.buildPlot(ctx), ctx, l.scale, path)
+}
 
-	p := plot.New()
-	setLabels(p, l.Labels)
+// buildPlot creates the plot from the current data.
+func (l *Lines) buildPlot(ctx *window.Context) *plot.Plot {
+	p := newPlot(ctx, l.Labels)
 
 	p.X.Tick.Marker = removeLastTicks{}
 
@@ -107,8 +108,8 @@ func (l *Lines) Draw(w *ecs.World, win *opengl.Window) {
 		p.X.Max = l.XLim[1]
 	}
 
-	p.Legend = plot.NewLegend()
-	p.Legend.TextStyle.Font.Variant = "Mono"
+	p.Legend = newLegend(ctx)
+	style := newSeriesStyle(ctx, l.Palette, l.CycleStyles)
 
 	for i := 0; i < len(l.series); i++ {
 		idx := l.yIndices[i]
@@ -116,19 +117,13 @@ func (l *Lines) Draw(w *ecs.World, win *opengl.Window) {
 		if err != nil {
 			panic(err)
 		}
-		lines.Color = defaultColors[i%len(defaultColors)]
+		lines.Color = style.Color(i)
+		lines.Dashes = style.Dashes(i)
 		p.Add(lines)
 		p.Legend.Add(l.headers[idx], lines)
 	}
 
-	win.Clear(color.White)
-	p.Draw(draw.New(c))
-
-	img := c.Image()
-	picture := pixel.PictureDataFromImage(img)
-
-	sprite := pixel.NewSprite(picture, picture.Bounds())
-	sprite.Draw(win, pixel.IM.Moved(pixel.V(picture.Rect.W()/2.0+5, picture.Rect.H()/2.0+5)))
+	return p
 }
 
 func (l *Lines) updateData(w *ecs.World) {
@@ -63,6 +63,23 @@ func TestLines(t *testing.T) {
 	m.Run()
 }
 
+func TestLines_Styles(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+	m.AddUISystem((&window.Window{}).
+		With(&plot.Lines{
+			Observer:    &TableObserver{},
+			Palette:     window.TableauPalette(),
+			CycleStyles: true,
+		}))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+}
+
 func TestLines_PanicX(t *testing.T) {
 	m := model.New()
 	m.AddUISystem((&window.Window{}).
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	px "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/gopxl/pixel/v2/ext/imdraw"
 	"github.com/gopxl/pixel/v2/ext/text"
 	"github.com/mlange-42/arche-pixel/window"
@@ -26,11 +25,11 @@ const (
 	tsLast
 )
 
-var (
-	colorGreen     = color.RGBA{0, 130, 40, 255}
-	colorDarkGreen = color.RGBA{20, 80, 25, 255}
-	colorCyan      = color.RGBA{0, 100, 120, 255}
-	colorDarkCyan  = color.RGBA{20, 50, 70, 255}
+const (
+	archetypeColor = 2   // Series color index of archetypes without entity relations.
+	relationColor  = 4   // Series color index of archetypes with entity relations.
+	usedAlpha      = 128 // Opacity of the bar part for used capacity.
+	reservedAlpha  = 50  // Opacity of the bar part for reserved capacity.
 )
 
 // NewMonitorWindow creates a window with [Monitor] drawer, for immediate use as a system.
@@ -51,11 +50,13 @@ func NewMonitorWindow(drawInterval int) *window.Window {
 
 // Monitor drawer for visualizing world and performance statistics.
 //
-// Symbology:
-//   - Green bars: archetypes without entity relations
-//   - Cyan bars: archetypes with entity relations
-//   - Light green/cyan: currently used
-//   - Dark green/cyan: reserved
+// Symbology, with colors from the palette of the window's [window.Theme]:
+//   - Third series color: archetypes without entity relations
+//   - Fifth series color: archetypes with entity relations
+//   - Stronger part: currently used
+//   - Fainter part: reserved
+//
+// Bars are blended with the background, so that text on them stays readable with any theme.
 //
 // Top info:
 //   - Tick: current model tick
@@ -72,6 +73,7 @@ type Monitor struct {
 	SampleInterval time.Duration // Approx. time between measurements for time series plots. Optional, default 1 second.
 	HidePlots      bool          // Hides time series plots
 	HideArchetypes bool          // Hides archetype stats
+	Font           *text.Atlas   // Font for text. Optional, default the window's font.
 	scale          float64
 	drawer         imdraw.IMDraw
 	summary        *text.Text
@@ -85,7 +87,7 @@ type Monitor struct {
 }
 
 // Initialize the system
-func (m *Monitor) Initialize(w *ecs.World, win *opengl.Window) {
+func (m *Monitor) Initialize(w *ecs.World, ctx *window.Context) {
 	if m.PlotCapacity <= 0 {
 		m.PlotCapacity = 300
 	}
@@ -99,20 +101,23 @@ func (m *Monitor) Initialize(w *ecs.World, win *opengl.Window) {
 
 	m.scale = calcScaleCorrection()
 
-	m.summary = text.New(px.V(0, 0), defaultFont)
+	m.summary = text.New(px.V(0, 0), fontOr(m.Font, ctx))
 	m.summary.AlignedTo(px.BottomRight)
+	m.summary.Color = ctx.Theme.Foreground
 
 	m.timeSeries = newTimeSeries(m.PlotCapacity)
 	for i := 0; i < len(m.timeSeries.Text); i++ {
-		m.timeSeries.Text[i] = text.New(px.V(0, 0), defaultFont)
+		m.timeSeries.Text[i] = text.New(px.V(0, 0), fontOr(m.Font, ctx))
+		m.timeSeries.Text[i].Color = ctx.Theme.Foreground
 	}
 	fmt.Fprintf(m.timeSeries.Text[tsEntities], "Entities")
 	fmt.Fprintf(m.timeSeries.Text[tsEntityCap], "Capacity")
 	fmt.Fprintf(m.timeSeries.Text[tsMemory], "Memory")
 	fmt.Fprintf(m.timeSeries.Text[tsTickPerSec], "TPS")
 
-	m.text = text.New(px.V(0, 0), defaultFont)
-	m.text.Color = color.RGBA{200, 200, 200, 255}
+	m.text = text.New(px.V(0, 0), fontOr(m.Font, ctx))
+	m.text.Color = ctx.Theme.Foreground
+	m.archetypes = archetypes{Font: fontOr(m.Font, ctx), Color: ctx.Theme.Foreground}
 
 	m.step = 0
 }
@@ -135,15 +140,14 @@ func (m *Monitor) Update(w *ecs.World) {
 }
 
 // UpdateInputs handles input events of the previous frame update.
-func (m *Monitor) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (m *Monitor) UpdateInputs(w *ecs.World, ctx *window.Context) {}
 
 // Draw the system
-func (m *Monitor) Draw(w *ecs.World, win *opengl.Window) {
+func (m *Monitor) Draw(w *ecs.World, ctx *window.Context) {
 	stats := w.Stats()
 	m.archetypes.Update(stats)
 
-	width := win.Canvas().Bounds().W()
-	height := win.Canvas().Bounds().H()
+	width, height := ctx.Bounds.W(), ctx.Bounds.H()
 
 	m.summary.Clear()
 	mem, units := toMemText(stats.Memory)
@@ -178,7 +182,7 @@ func (m *Monitor) Draw(w *ecs.World, win *opengl.Window) {
 	x0 := 6.0
 	y0 := height - 18.0
 
-	m.summary.Draw(win, px.IM.Moved(px.V(x0, y0+10)))
+	m.summary.Draw(ctx, px.IM.Moved(px.V(x0, y0+10)))
 	y0 -= 10
 
 	if split {
@@ -195,11 +199,11 @@ func (m *Monitor) Draw(w *ecs.World, win *opengl.Window) {
 		if m.HideArchetypes {
 			plotWidth = width - 20
 		}
-		m.drawPlot(win, x0, plotY0-plotHeight, plotWidth, plotHeight, tsEntities, tsEntityCap)
+		m.drawPlot(ctx, x0, plotY0-plotHeight, plotWidth, plotHeight, tsEntities, tsEntityCap)
 		plotY0 -= plotHeight + 10
-		m.drawPlot(win, x0, plotY0-plotHeight, plotWidth, plotHeight, tsMemory)
+		m.drawPlot(ctx, x0, plotY0-plotHeight, plotWidth, plotHeight, tsMemory)
 		plotY0 -= plotHeight + 10
-		m.drawPlot(win, x0, plotY0-plotHeight, plotWidth, plotHeight, tsTickPerSec)
+		m.drawPlot(ctx, x0, plotY0-plotHeight, plotWidth, plotHeight, tsTickPerSec)
 
 		x0 += math.Ceil(plotWidth + 10)
 	}
@@ -212,27 +216,27 @@ func (m *Monitor) Draw(w *ecs.World, win *opengl.Window) {
 				archHeight = 20
 			}
 			m.drawArchetypeScales(
-				win, x0, y0-archHeight, archWidth, archHeight, maxCapacity,
+				ctx, x0, y0-archHeight, archWidth, archHeight, maxCapacity,
 			)
 			for i := 0; i < numNodes; i++ {
 				idx := m.archetypes.Indices[i]
 				m.drawArchetype(
-					win, x0, y0-float64(i+2)*archHeight, archWidth, archHeight,
+					ctx, x0, y0-float64(i+2)*archHeight, archWidth, archHeight,
 					maxCapacity, &stats.Nodes[idx], m.archetypes.Components[i],
 				)
 			}
 		} else {
 			m.text.Clear()
 			fmt.Fprintf(m.text, "Too many archetypes")
-			m.text.Draw(win, px.IM.Moved(px.V(x0, y0-10)))
+			m.text.Draw(ctx, px.IM.Moved(px.V(x0, y0-10)))
 		}
 	}
 
-	dr.Draw(win)
+	dr.Draw(ctx)
 	dr.Clear()
 }
 
-func (m *Monitor) drawArchetypeScales(win *opengl.Window, x, y, w, h float64, max int) {
+func (m *Monitor) drawArchetypeScales(ctx *window.Context, x, y, w, h float64, max int) {
 	dr := &m.drawer
 	step := calcTicksStep(float64(max), 8)
 	if step < 1 {
@@ -240,7 +244,7 @@ func (m *Monitor) drawArchetypeScales(win *opengl.Window, x, y, w, h float64, ma
 	}
 	drawStep := w * step / float64(max)
 
-	dr.Color = color.RGBA{140, 140, 140, 255}
+	dr.Color = ctx.Theme.Grid
 	dr.Push(px.V(x, y+2), px.V(x+w, y+2))
 	dr.Line(1)
 	dr.Reset()
@@ -255,55 +259,51 @@ func (m *Monitor) drawArchetypeScales(win *opengl.Window, x, y, w, h float64, ma
 		val := i * int(step)
 		m.text.Clear()
 		fmt.Fprintf(m.text, "%d", val)
-		m.text.Draw(win, px.IM.Moved(px.V(math.Floor(x+xi*drawStep-m.text.Bounds().W()/2), y+10)))
+		m.text.Draw(ctx, px.IM.Moved(px.V(math.Floor(x+xi*drawStep-m.text.Bounds().W()/2), y+10)))
 	}
 }
 
-func (m *Monitor) drawArchetype(win *opengl.Window, x, y, w, h float64, max int, node *stats.Node, text *text.Text) {
+func (m *Monitor) drawArchetype(ctx *window.Context, x, y, w, h float64, max int, node *stats.Node, text *text.Text) {
 	dr := &m.drawer
 
 	cap := float64(node.Capacity) / float64(max)
 	cnt := float64(node.Size) / float64(max)
 
+	col := ctx.Theme.SeriesColor(archetypeColor)
 	if node.HasRelation {
-		dr.Color = colorCyan
-	} else {
-		dr.Color = colorGreen
+		col = ctx.Theme.SeriesColor(relationColor)
 	}
+	dr.Color = window.Fade(col, usedAlpha)
 	dr.Push(px.V(x, y), px.V(x+w*cnt, y+h))
 	dr.Rectangle(0)
 	dr.Reset()
 
-	if node.HasRelation {
-		dr.Color = colorDarkCyan
-	} else {
-		dr.Color = colorDarkGreen
-	}
+	dr.Color = window.Fade(col, reservedAlpha)
 	dr.Push(px.V(x+w*cnt, y), px.V(x+w*cap, y+h))
 	dr.Rectangle(0)
 	dr.Reset()
 
-	dr.Color = color.RGBA{40, 40, 40, 255}
+	dr.Color = ctx.Theme.Grid
 	dr.Push(px.V(x, y), px.V(x+w, y+h))
 	dr.Rectangle(1)
 	dr.Reset()
 
-	dr.Draw(win)
+	dr.Draw(ctx)
 	dr.Clear()
 
-	text.Draw(win, px.IM.Moved(px.V(x+3, y+3)))
+	text.Draw(ctx, px.IM.Moved(px.V(x+3, y+3)))
 
 	if node.HasRelation {
 		m.text.Clear()
 		fmt.Fprintf(m.text, "%5d / %5d", node.ActiveArchetypeCount, node.ArchetypeCount)
-		m.text.Draw(win, px.IM.Moved(px.V(x+3, y+3)))
+		m.text.Draw(ctx, px.IM.Moved(px.V(x+3, y+3)))
 	}
 }
 
-func (m *Monitor) drawPlot(win *opengl.Window, x, y, w, h float64, series ...timeSeriesType) {
+func (m *Monitor) drawPlot(ctx *window.Context, x, y, w, h float64, series ...timeSeriesType) {
 	dr := &m.drawer
 
-	dr.Color = color.RGBA{0, 25, 10, 255}
+	dr.Color = ctx.Theme.Background
 	dr.Push(px.V(x, y), px.V(x+w, y+h))
 	dr.Rectangle(0)
 	dr.Reset()
@@ -320,7 +320,7 @@ func (m *Monitor) drawPlot(win *opengl.Window, x, y, w, h float64, series ...tim
 		}
 	}
 
-	dr.Color = color.White
+	dr.Color = ctx.Theme.Foreground
 	for _, series := range series {
 		values := m.timeSeries.Values[series]
 		numValues := values.Len()
@@ -340,17 +340,17 @@ func (m *Monitor) drawPlot(win *opengl.Window, x, y, w, h float64, series ...tim
 		}
 	}
 
-	dr.Color = color.RGBA{140, 140, 140, 255}
+	dr.Color = ctx.Theme.Grid
 	dr.Push(px.V(x, y), px.V(x+w, y+h))
 	dr.Rectangle(1)
 	dr.Reset()
 
-	dr.Draw(win)
+	dr.Draw(ctx)
 	dr.Clear()
 
 	if len(series) > 0 {
 		text := m.timeSeries.Text[series[0]]
-		text.Draw(win, px.IM.Moved(px.V(x+w-text.Bounds().W()-3, y+3)))
+		text.Draw(ctx, px.IM.Moved(px.V(x+w-text.Bounds().W()-3, y+3)))
 	}
 }
 
@@ -416,6 +416,8 @@ func (t *frameTimer) FPS() float64 {
 }
 
 type archetypes struct {
+	Font       *text.Atlas
+	Color      color.Color
 	Components []*text.Text
 	Indices    []int
 }
@@ -437,8 +439,8 @@ func (a *archetypes) Update(stats *stats.World) {
 		if !node.IsActive {
 			continue
 		}
-		text := text.New(px.V(0, 0), defaultFont)
-		text.Color = color.RGBA{200, 200, 200, 255}
+		text := text.New(px.V(0, 0), a.Font)
+		text.Color = a.Color
 		sb := strings.Builder{}
 		sb.WriteString(fmt.Sprintf("              %4d B: ", node.MemoryPerEntity))
 		types := node.ComponentTypes
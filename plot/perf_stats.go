@@ -2,13 +2,12 @@ package plot
 
 import (
 	"fmt"
-	"image/color"
 	"time"
 
 	px "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/gopxl/pixel/v2/ext/imdraw"
 	"github.com/gopxl/pixel/v2/ext/text"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
 )
 
@@ -17,6 +16,7 @@ import (
 // Adds an overlay with performance statistics in the top left corner of the window.
 type PerfStats struct {
 	SampleInterval time.Duration // Approx. time between measurements. Optional, default 1 second.
+	Font           *text.Atlas   // Font for text. Optional, default the window's font.
 	drawer         imdraw.IMDraw
 	stats          tempStats
 	summary        *text.Text
@@ -27,7 +27,7 @@ type PerfStats struct {
 }
 
 // Initialize the system
-func (p *PerfStats) Initialize(w *ecs.World, win *opengl.Window) {
+func (p *PerfStats) Initialize(w *ecs.World, ctx *window.Context) {
 	if p.SampleInterval <= 0 {
 		p.SampleInterval = time.Second
 	}
@@ -36,8 +36,9 @@ func (p *PerfStats) Initialize(w *ecs.World, win *opengl.Window) {
 
 	p.drawer = *imdraw.New(nil)
 
-	p.summary = text.New(px.V(0, 0), defaultFont)
+	p.summary = text.New(px.V(0, 0), fontOr(p.Font, ctx))
 	p.summary.AlignedTo(px.BottomRight)
+	p.summary.Color = ctx.Theme.Foreground
 
 	p.step = 0
 
@@ -62,10 +63,10 @@ func (p *PerfStats) Update(w *ecs.World) {
 }
 
 // UpdateInputs handles input events of the previous frame update.
-func (p *PerfStats) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (p *PerfStats) UpdateInputs(w *ecs.World, ctx *window.Context) {}
 
 // Draw the system
-func (p *PerfStats) Draw(w *ecs.World, win *opengl.Window) {
+func (p *PerfStats) Draw(w *ecs.World, ctx *window.Context) {
 	p.summary.Clear()
 	mem, units := toMemText(p.stats.Mem)
 	fmt.Fprintf(
@@ -76,26 +77,26 @@ func (p *PerfStats) Draw(w *ecs.World, win *opengl.Window) {
 	)
 
 	dr := &p.drawer
-	height := win.Canvas().Bounds().H()
+	height := ctx.Bounds.H()
 	x0 := 10.0
 	y0 := height - 10.0
 
 	v1 := px.V(x0+p.summary.Bounds().Min.X-5, y0+p.summary.Bounds().Min.Y-12)
 	v2 := px.V(x0+p.summary.Bounds().Max.X+5, y0+p.summary.Bounds().Max.Y-8)
 
-	dr.Color = color.Black
+	dr.Color = ctx.Theme.Background
 	dr.Push(v1, v2)
 	dr.Rectangle(0)
 
-	dr.Color = color.White
+	dr.Color = ctx.Theme.Foreground
 	dr.Push(v1, v2)
 	dr.Rectangle(1)
 
-	dr.Draw(win)
+	dr.Draw(ctx)
 	dr.Reset()
 	dr.Clear()
 
-	p.summary.Draw(win, px.IM.Moved(px.V(x0, y0)))
+	p.summary.Draw(ctx, px.IM.Moved(px.V(x0, y0)))
 
 }
 
@@ -0,0 +1,100 @@
+package plot
+
+import (
+	"image/color"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/mlange-42/arche-model/resource"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+)
+
+// Picker drawer for selecting entities with the mouse.
+//
+// On click, selects the entity nearest to the mouse cursor, within Radius.
+// Clicking where there is no entity clears the selection.
+// The selected entity is written to the SelectedEntity resource ([github.com/mlange-42/arche-model/resource.SelectedEntity]),
+// which is added to the world if not present.
+// It can be shown with the [Inspector] drawer.
+// The selection is highlighted by a circle around the entity.
+//
+// Entities are considered if they have a component of type P, and their position is obtained via the Position function.
+// Positions are interpreted in drawing coordinates.
+// To map world coordinates to the screen, use the picker in a [window.Camera], together with the drawers that show the entities.
+// Entities are looked up in a spatial index, which is updated once per model tick, on demand.
+type Picker[P any] struct {
+	Position func(p *P) px.Vec // Function to get the position from a component. Required.
+	Radius   float64           // Radius for picking and of the highlight marker, in pixels. Optional, default 10.
+	Button   px.Button         // Mouse button for picking. Optional, default left mouse button.
+	Color    color.Color       // Color of the highlight marker. Optional, default the theme's accent color.
+	filter   generic.Filter1[P]
+	posMap   generic.Map[P]
+	selected generic.Resource[resource.SelectedEntity]
+	index    spatialIndex
+	dirty    bool
+	drawer   imdraw.IMDraw
+}
+
+// Initialize the drawer.
+func (p *Picker[P]) Initialize(w *ecs.World, ctx *window.Context) {
+	if p.Position == nil {
+		panic("picker requires a Position function")
+	}
+	if p.Radius <= 0 {
+		p.Radius = 10
+	}
+	if p.Color == nil {
+		p.Color = ctx.Theme.Accent
+	}
+
+	p.filter = *generic.NewFilter1[P]()
+	p.posMap = generic.NewMap[P](w)
+	p.selected = generic.NewResource[resource.SelectedEntity](w)
+	if !p.selected.Has() {
+		p.selected.Add(&resource.SelectedEntity{})
+	}
+
+	p.index = newSpatialIndex(p.Radius)
+	p.dirty = true
+	p.drawer = *imdraw.New(nil)
+}
+
+// Update the drawer.
+func (p *Picker[P]) Update(w *ecs.World) {
+	p.dirty = true
+}
+
+// UpdateInputs handles input events of the previous frame update.
+func (p *Picker[P]) UpdateInputs(w *ecs.World, ctx *window.Context) {
+	if !ctx.JustPressed(p.Button) {
+		return
+	}
+	radius := p.Radius / matrixScale(ctx.Matrix)
+	updateIndex(w, &p.index, &p.dirty, &p.filter, p.Position, radius)
+	e, _ := p.index.Nearest(ctx.MousePosition(), radius)
+	p.selected.Get().Selected = e
+}
+
+// Draw the drawer.
+func (p *Picker[P]) Draw(w *ecs.World, ctx *window.Context) {
+	sel := p.selected.Get().Selected
+	if sel.IsZero() || !w.Alive(sel) || !p.posMap.Has(sel) {
+		return
+	}
+	scale := matrixScale(ctx.Matrix)
+
+	dr := &p.drawer
+	dr.Color = p.Color
+	dr.Push(p.Position(p.posMap.Get(sel)))
+	dr.Circle(p.Radius/scale, 2/scale)
+	dr.Reset()
+	dr.Draw(ctx)
+	dr.Clear()
+}
+
+// Shortcuts returns the drawer's mouse shortcuts.
+func (p *Picker[P]) Shortcuts() []window.Shortcut {
+	return []window.Shortcut{{Keys: p.Button.String(), Description: "Select entity"}}
+}
@@ -0,0 +1,99 @@
+package plot_test
+
+import (
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/resource"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/plot"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExamplePicker() {
+	// Create a new model.
+	m := model.New()
+
+	// Limit the the simulation speed.
+	m.TPS = 30
+
+	// Create some entities.
+	builder := generic.NewMap1[Position](&m.World)
+	builder.NewWith(&Position{X: 100, Y: 100})
+	builder.NewWith(&Position{X: 200, Y: 150})
+
+	// Create a picker for selecting entities with a Position component.
+	picker := plot.Picker[Position]{
+		Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+	}
+
+	// Add the picker to a camera, and inspect the selected entity in a second window.
+	m.AddUISystem((&window.Window{}).
+		With((&window.Camera{}).With(&picker)))
+	m.AddUISystem((&window.Window{}).
+		With(&plot.Inspector{}))
+
+	// Add a termination system that ends the simulation.
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+
+	// Run the simulation.
+	// Due to the use of the OpenGL UI system, the model must be run via [window.Run].
+	// Comment out the code line above, and uncomment the next line to run this example stand-alone.
+
+	// window.Run(m)
+
+	// Output:
+}
+
+func TestPicker(t *testing.T) {
+	m := model.New()
+
+	builder := generic.NewMap1[Position](&m.World)
+	e1 := builder.NewWith(&Position{X: 100, Y: 100})
+	e2 := builder.NewWith(&Position{X: 110, Y: 100})
+
+	input := keyInput{key: px.MouseButtonLeft}
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), &input)
+
+	picker := plot.Picker[Position]{
+		Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+	}
+	picker.Initialize(&m.World, ctx)
+	sel := ecs.GetResource[resource.SelectedEntity](&m.World)
+
+	input.mouse = px.V(103, 101)
+	picker.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, e1, sel.Selected)
+
+	input.mouse = px.V(108, 95)
+	picker.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, e2, sel.Selected)
+
+	input.mouse = px.V(200, 100)
+	picker.UpdateInputs(&m.World, ctx)
+	assert.True(t, sel.Selected.IsZero())
+
+	ctx.Matrix = px.IM.Scaled(px.ZV, 0.1)
+	input.mouse = px.V(200, 100)
+	picker.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, e2, sel.Selected)
+}
+
+func TestPicker_Panic(t *testing.T) {
+	m := model.New()
+	m.AddUISystem((&window.Window{}).
+		With(&plot.Picker[Position]{}))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	assert.Panics(t, m.Run)
+}
@@ -3,9 +3,10 @@ package plot
 import (
 	"fmt"
 	"image/color"
+	"strings"
 
 	pixel "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
+	"github.com/gopxl/pixel/v2/ext/text"
 	"github.com/mlange-42/arche-model/observer"
 	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
@@ -16,19 +17,28 @@ import (
 // Draws an image from a Matrix observer per RGB color channel.
 // The image is scaled to the canvas extent, with preserved aspect ratio.
 // Does not add plot axes etc.
+//
+// When the mouse is over the image, the column and row of the cell under the cursor are shown, together with its channel values.
 type ImageRGB struct {
-	Scale    float64               // Spatial scaling: cell size in screen pixels. Optional, default auto.
-	Observer observer.MatrixLayers // Observer providing data for color channels.
-	Layers   []int                 // Layer indices. Optional, defaults to [0, 1, 2]. Use -1 to ignore a channel.
-	Min      []float64             // Minimum value for channel color mapping. Optional, default [0, 0, 0].
-	Max      []float64             // Maximum value for channel color mapping. Optional, default [1, 1, 1].
-	slope    []float64
-	dataLen  int
-	picture  *pixel.PictureData
+	Scale       float64               // Spatial scaling: cell size in screen pixels. Optional, default auto.
+	Observer    observer.MatrixLayers // Observer providing data for color channels.
+	Layers      []int                 // Layer indices. Optional, defaults to [0, 1, 2]. Use -1 to ignore a channel.
+	Min         []float64             // Minimum value for channel color mapping. Optional, default [0, 0, 0].
+	Max         []float64             // Maximum value for channel color mapping. Optional, default [1, 1, 1].
+	ColorBar    *ColorBar             // Color bar legend with a bar per channel, drawn next to the image. Optional, default none.
+	Names       []string              // Names of the channels, shown in the color bar and readout. Optional, default ["Red", "Green", "Blue"].
+	HideReadout bool                  // Hides the readout of the cell under the mouse cursor. Optional, default false.
+	Font        *text.Atlas           // Font for the readout. Optional, default the window's font.
+	slope       []float64
+	dataLen     int
+	channels    []colorBarChannel
+	picture     *pixel.PictureData
+	values      [][]float64
+	readout     imageReadout
 }
 
 // Initialize the drawer.
-func (i *ImageRGB) Initialize(w *ecs.World, win *opengl.Window) {
+func (i *ImageRGB) Initialize(w *ecs.World, ctx *window.Context) {
 	i.Observer.Initialize(w)
 
 	if i.Layers == nil {
@@ -63,9 +73,22 @@ func (i *ImageRGB) Initialize(w *ecs.World, win *opengl.Window) {
 		1.0 / (i.Max[2] - i.Min[2]),
 	}
 
+	if i.Names == nil {
+		i.Names = []string{"Red", "Green", "Blue"}
+	} else if len(i.Names) != 3 {
+		panic("RgbImage plot needs exactly 3 Names")
+	}
+
 	width, height := i.Observer.Dims()
 	i.dataLen = width * height
 	i.picture = pixel.MakePictureData(pixel.R(0, 0, float64(width), float64(height)))
+
+	if i.ColorBar != nil {
+		i.ColorBar.initialize(ctx)
+		i.channels = i.colorBarChannels()
+	}
+	i.readout = newImageReadout(ctx, i.Font, width, height)
+	i.values = nil
 }
 
 // Update the drawer.
@@ -74,11 +97,17 @@ func (i *ImageRGB) Update(w *ecs.World) {
 }
 
 // UpdateInputs handles input events of the previous frame update.
-func (i *ImageRGB) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (i *ImageRGB) UpdateInputs(w *ecs.World, ctx *window.Context) {
+	if i.HideReadout {
+		return
+	}
+	i.readout.updateInputs(ctx)
+}
 
 // Draw the drawer.
-func (i *ImageRGB) Draw(w *ecs.World, win *opengl.Window) {
+func (i *ImageRGB) Draw(w *ecs.World, ctx *window.Context) {
 	cannels := i.Observer.Values(w)
+	i.values = cannels
 
 	values := append([]float64{}, i.Min...)
 	for j := 0; j < i.dataLen; j++ {
@@ -90,16 +119,60 @@ func (i *ImageRGB) Draw(w *ecs.World, win *opengl.Window) {
 		i.picture.Pix[j] = i.valuesToColor(values[0], values[1], values[2])
 	}
 
-	scale := i.Scale
-	if i.Scale <= 0 {
-		scale = window.Scale(win, i.picture.Rect.W(), i.picture.Rect.H())
+	i.readout.rect = drawPicture(ctx, i.picture, i.Scale, i.ColorBar, i.channels)
+
+	if !i.HideReadout && i.readout.hovered {
+		i.readout.draw(ctx, i.readoutText())
 	}
+}
 
-	sprite := pixel.NewSprite(i.picture, i.picture.Bounds())
-	sprite.Draw(win,
-		pixel.IM.Moved(pixel.V(i.picture.Rect.W()/2.0, i.picture.Rect.H()/2.0)).
-			Scaled(pixel.Vec{}, scale),
-	)
+// Hovered returns the column and row of the cell under the mouse cursor.
+// The last return value is false if the mouse is not over the image.
+func (i *ImageRGB) Hovered() (col, row int, ok bool) {
+	return i.readout.col, i.readout.row, i.readout.hovered
+}
+
+// readoutText formats the coordinates and channel values of the hovered cell.
+func (i *ImageRGB) readoutText() string {
+	r := &i.readout
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "x %d, y %d", r.col, r.row)
+	for c, k := range i.Layers {
+		if k >= 0 {
+			fmt.Fprintf(&b, "\n%s: %.4g", i.Names[c], i.values[k][r.index()])
+		}
+	}
+	return b.String()
+}
+
+// colorBarChannels creates the color bar channels for all used layers.
+func (i *ImageRGB) colorBarChannels() []colorBarChannel {
+	channels := []colorBarChannel{}
+	for c, k := range i.Layers {
+		if k < 0 {
+			continue
+		}
+		c := c
+		channels = append(channels, colorBarChannel{
+			Name: i.Names[c],
+			Min:  i.Min[c],
+			Max:  i.Max[c],
+			Color: func(v float64) color.RGBA {
+				col := color.RGBA{A: 0xff}
+				value := norm(v, i.Min[c], i.slope[c])
+				switch c {
+				case 0:
+					col.R = value
+				case 1:
+					col.G = value
+				default:
+					col.B = value
+				}
+				return col
+			},
+		})
+	}
+	return channels
 }
 
 func (i *ImageRGB) valuesToColor(r, g, b float64) color.RGBA {
@@ -67,6 +67,29 @@ func TestImageRGB(t *testing.T) {
 	m.Run()
 }
 
+func TestImageRGB_ColorBar(t *testing.T) {
+	for _, pos := range []plot.ColorBarPosition{plot.ColorBarRight, plot.ColorBarBottom} {
+		m := model.New()
+		m.TPS = 300
+		m.AddUISystem((&window.Window{}).
+			With(&plot.ImageRGB{
+				Observer: observer.MatrixToLayers(
+					&CallbackMatrixObserver{Callback: func(i, j int) float64 { return float64(i) / 240 }},
+					&CallbackMatrixObserver{Callback: func(i, j int) float64 { return math.Sin(0.1 * float64(i)) }},
+				),
+				Layers:   []int{0, -1, 1},
+				Min:      []float64{0, 0, -1},
+				Max:      []float64{1, 1, 1},
+				Names:    []string{"Prey", "", "Predators"},
+				ColorBar: &plot.ColorBar{Position: pos, Label: "Density"},
+			}))
+		m.AddSystem(&system.FixedTermination{
+			Steps: 100,
+		})
+		m.Run()
+	}
+}
+
 func TestImageRGB_PanicMin(t *testing.T) {
 	m := model.New()
 	m.TPS = 300
@@ -4,15 +4,11 @@ import (
 	"fmt"
 	"image/color"
 
-	pixel "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/mlange-42/arche-model/observer"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
-	"gonum.org/v1/plot/vg"
-	"gonum.org/v1/plot/vg/draw"
-	"gonum.org/v1/plot/vg/vgimg"
 )
 
 // Scatter plot drawer.
@@ -20,12 +16,14 @@ import (
 // Creates a scatter plot from multiple observers.
 // Supports multiple series per observer. The series in a particular observer must share a common X column.
 type Scatter struct {
-	Observers []observer.Table // Observers providing XY data series.
-	X         []string         // X column name per observer. Optional. Defaults to first column. Empty strings also falls back to the default.
-	Y         [][]string       // Y column names per observer. Optional. Defaults to second column. Empty strings also falls back to the default.
-	XLim      [2]float64       // X axis limits. Optional, default auto.
-	YLim      [2]float64       // Y axis limits. Optional, default auto.
-	Labels    Labels           // Labels for plot and axes. Optional.
+	Observers   []observer.Table // Observers providing XY data series.
+	X           []string         // X column name per observer. Optional. Defaults to first column. Empty strings also falls back to the default.
+	Y           [][]string       // Y column names per observer. Optional. Defaults to second column. Empty strings also falls back to the default.
+	XLim        [2]float64       // X axis limits. Optional, default auto.
+	YLim        [2]float64       // Y axis limits. Optional, default auto.
+	Labels      Labels           // Labels for plot and axes. Optional.
+	Palette     []color.Color    // Colors of the series. Optional, default the palette of the window's theme.
+	CycleStyles bool             // Vary the marker shape with every series, for grayscale print. Optional, default only when colors repeat.
 
 	xIndices []int
 	yIndices [][]int
@@ -36,7 +34,7 @@ type Scatter struct {
 }
 
 // Initialize the drawer.
-func (s *Scatter) Initialize(w *ecs.World, win *opengl.Window) {
+func (s *Scatter) Initialize(w *ecs.World, ctx *window.Context) {
 	numObs := len(s.Observers)
 	if len(s.X) != 0 && len(s.X) != numObs {
 		panic("length of X not equal to length of Observers")
@@ -94,19 +92,22 @@ func (s *Scatter) Update(w *ecs.World) {
 }
 
 // UpdateInputs handles input events of the previous frame update.
-func (s *Scatter) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (s *Scatter) UpdateInputs(w *ecs.World, ctx *window.Context) {}
 
 // Draw the drawer.
-func (s *Scatter) Draw(w *ecs.World, win *opengl.Window) {
-	width := win.Canvas().Bounds().W()
-	height := win.Canvas().Bounds().H()
-
+func (s *Scatter) Draw(w *ecs.World, ctx *window.Context) {
 	s.updateData(w)
+	renderPlot(s.buildPlot(ctx), ctx, s.scale)
+}
 
-	c := vgimg.New(vg.Points(width*s.scale)-10, vg.Points(height*s.scale)-10)
+// ExportVector writes the plot to a vector graphics file.
+func (s *Scatter) ExportVector(w *ecs.World, ctx *window.Context, path string) error {
+	return savePlot(s.buildPlot(ctx), ctx, s.scale, path)
+}
 
-	p := plot.New()
-	setLabels(p, s.Labels)
+// buildPlot creates the plot from the current data.
+func (s *Scatter) buildPlot(ctx *window.Context) *plot.Plot {
+	p := newPlot(ctx, s.Labels)
 
 	p.X.Tick.Marker = removeLastTicks{}
 
@@ -119,8 +120,8 @@ func (s *Scatter) Draw(w *ecs.World, win *opengl.Window) {
 		p.Y.Max = s.YLim[1]
 	}
 
-	p.Legend = plot.NewLegend()
-	p.Legend.TextStyle.Font.Variant = "Mono"
+	p.Legend = newLegend(ctx)
+	style := newSeriesStyle(ctx, s.Palette, s.CycleStyles)
 
 	cnt := 0
 	for i := 0; i < len(s.xIndices); i++ {
@@ -130,22 +131,15 @@ func (s *Scatter) Draw(w *ecs.World, win *opengl.Window) {
 			if err != nil {
 				panic(err)
 			}
-			points.Shape = draw.CircleGlyph{}
-			points.Color = defaultColors[cnt%len(defaultColors)]
+			points.Shape = style.Glyph(cnt)
+			points.Color = style.Color(cnt)
 			p.Add(points)
 			p.Legend.Add(s.labels[i][j], points)
 			cnt++
 		}
 	}
 
-	win.Clear(color.White)
-	p.Draw(draw.New(c))
-
-	img := c.Image()
-	picture := pixel.PictureDataFromImage(img)
-
-	sprite := pixel.NewSprite(picture, picture.Bounds())
-	sprite.Draw(win, pixel.IM.Moved(pixel.V(picture.Rect.W()/2.0+5, picture.Rect.H()/2.0+5)))
+	return p
 }
 
 func (s *Scatter) updateData(w *ecs.World) {
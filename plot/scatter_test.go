@@ -66,6 +66,26 @@ func TestScatter(t *testing.T) {
 	m.Run()
 }
 
+func TestScatter_Styles(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+
+	m.AddUISystem((&window.Window{}).
+		With(&plot.Scatter{
+			Observers: []observer.Table{
+				&TableObserver{},
+			},
+			Y:           [][]string{{"A", "B", "C"}},
+			Palette:     window.OkabeItoPalette()[:2],
+			CycleStyles: false,
+		}))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+	m.Run()
+}
+
 func TestScatter_PanicXCount(t *testing.T) {
 	m := model.New()
 	m.TPS = 300
@@ -0,0 +1,76 @@
+package plot
+
+import (
+	"image/color"
+
+	"github.com/mlange-42/arche-pixel/window"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// seriesDashes are the dash patterns cycled through for line series, starting with solid lines.
+var seriesDashes = [][]vg.Length{
+	nil,
+	{vg.Points(6), vg.Points(3)},
+	{vg.Points(2), vg.Points(2)},
+	{vg.Points(8), vg.Points(3), vg.Points(2), vg.Points(3)},
+	{vg.Points(12), vg.Points(4)},
+	{vg.Points(8), vg.Points(3), vg.Points(2), vg.Points(3), vg.Points(2), vg.Points(3)},
+}
+
+// seriesGlyphs are the marker shapes cycled through for scatter series, starting with circles.
+var seriesGlyphs = []draw.GlyphDrawer{
+	draw.CircleGlyph{},
+	draw.TriangleGlyph{},
+	draw.BoxGlyph{},
+	draw.PyramidGlyph{},
+	draw.RingGlyph{},
+	draw.SquareGlyph{},
+	draw.PlusGlyph{},
+	draw.CrossGlyph{},
+}
+
+// seriesStyle assigns colors, dash patterns and marker shapes to data series by their index.
+//
+// Colors cycle through the palette.
+// Dash patterns and marker shapes change with every series if cycle is true.
+// Otherwise, they change only when the palette colors start to repeat.
+type seriesStyle struct {
+	palette []color.Color
+	cycle   bool
+}
+
+// newSeriesStyle creates a series style from the given palette, or from the palette of the context's theme if it is empty.
+// If both are empty, the [window.OkabeItoPalette] is used, like in [window.Theme.SeriesColor].
+func newSeriesStyle(ctx *window.Context, palette []color.Color, cycle bool) seriesStyle {
+	if len(palette) == 0 {
+		palette = ctx.Theme.Palette
+	}
+	if len(palette) == 0 {
+		palette = window.OkabeItoPalette()
+	}
+	return seriesStyle{palette: palette, cycle: cycle}
+}
+
+// Color returns the color of the series with the given index.
+func (s *seriesStyle) Color(index int) color.Color {
+	return s.palette[index%len(s.palette)]
+}
+
+// Dashes returns the dash pattern of the series with the given index.
+func (s *seriesStyle) Dashes(index int) []vg.Length {
+	return seriesDashes[s.variant(index)%len(seriesDashes)]
+}
+
+// Glyph returns the marker shape of the series with the given index.
+func (s *seriesStyle) Glyph(index int) draw.GlyphDrawer {
+	return seriesGlyphs[s.variant(index)%len(seriesGlyphs)]
+}
+
+// variant returns the index of the dash pattern or marker shape of a series.
+func (s *seriesStyle) variant(index int) int {
+	if s.cycle {
+		return index
+	}
+	return index / len(s.palette)
+}
@@ -0,0 +1,36 @@
+package plot
+
+import (
+	"image/color"
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeriesStyle(t *testing.T) {
+	ctx := window.NewContext(nil, px.R(0, 0, 100, 100), nil)
+
+	style := newSeriesStyle(ctx, nil, false)
+	n := len(ctx.Theme.Palette)
+	assert.Equal(t, ctx.Theme.Palette[0], style.Color(0))
+	assert.Equal(t, ctx.Theme.Palette[0], style.Color(n))
+	assert.Nil(t, style.Dashes(0))
+	assert.Nil(t, style.Dashes(n-1))
+	assert.Equal(t, seriesDashes[1], style.Dashes(n))
+	assert.Equal(t, seriesGlyphs[0], style.Glyph(n-1))
+	assert.Equal(t, seriesGlyphs[1], style.Glyph(n))
+
+	palette := []color.Color{color.White, color.Black}
+	style = newSeriesStyle(ctx, palette, true)
+	assert.Equal(t, color.Black, style.Color(3))
+	assert.Equal(t, seriesDashes[1], style.Dashes(1))
+	assert.Equal(t, seriesDashes[0], style.Dashes(len(seriesDashes)))
+	assert.Equal(t, seriesGlyphs[2], style.Glyph(2))
+
+	ctx.Theme = &window.Theme{}
+	style = newSeriesStyle(ctx, nil, false)
+	assert.Equal(t, window.OkabeItoPalette()[1], style.Color(1))
+	assert.Equal(t, seriesGlyphs[1], style.Glyph(len(window.OkabeItoPalette())))
+}
@@ -0,0 +1,71 @@
+package plot
+
+import (
+	"math"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche/ecs"
+)
+
+// spatialIndex is a uniform grid for finding entities near a position.
+type spatialIndex struct {
+	cellSize float64
+	cells    map[[2]int][]indexEntry
+}
+
+type indexEntry struct {
+	Entity   ecs.Entity
+	Position px.Vec
+}
+
+func newSpatialIndex(cellSize float64) spatialIndex {
+	return spatialIndex{
+		cellSize: cellSize,
+		cells:    map[[2]int][]indexEntry{},
+	}
+}
+
+// Reset removes all entities and sets the cell size.
+// Re-uses the memory of cells that were occupied since the previous reset,
+// and removes cells that stayed empty, so that the index does not grow with moving entities.
+func (s *spatialIndex) Reset(cellSize float64) {
+	s.cellSize = cellSize
+	for k, v := range s.cells {
+		if len(v) == 0 {
+			delete(s.cells, k)
+			continue
+		}
+		s.cells[k] = v[:0]
+	}
+}
+
+// Add an entity at the given position.
+func (s *spatialIndex) Add(e ecs.Entity, pos px.Vec) {
+	cell := s.cell(pos)
+	s.cells[cell] = append(s.cells[cell], indexEntry{Entity: e, Position: pos})
+}
+
+// Nearest finds the entity that is nearest to the given position, within the given radius.
+func (s *spatialIndex) Nearest(pos px.Vec, radius float64) (ecs.Entity, bool) {
+	min, max := s.cell(pos.Sub(px.V(radius, radius))), s.cell(pos.Add(px.V(radius, radius)))
+
+	best := ecs.Entity{}
+	bestDist := radius * radius
+	found := false
+	for x := min[0]; x <= max[0]; x++ {
+		for y := min[1]; y <= max[1]; y++ {
+			for _, entry := range s.cells[[2]int{x, y}] {
+				d := entry.Position.Sub(pos)
+				dist := d.X*d.X + d.Y*d.Y
+				if dist <= bestDist {
+					best, bestDist, found = entry.Entity, dist, true
+				}
+			}
+		}
+	}
+	return best, found
+}
+
+func (s *spatialIndex) cell(pos px.Vec) [2]int {
+	return [2]int{int(math.Floor(pos.X / s.cellSize)), int(math.Floor(pos.Y / s.cellSize))}
+}
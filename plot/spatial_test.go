@@ -0,0 +1,78 @@
+package plot
+
+import (
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpatialIndex(t *testing.T) {
+	w := ecs.NewWorld()
+	e1, e2, e3 := w.NewEntity(), w.NewEntity(), w.NewEntity()
+
+	index := newSpatialIndex(10)
+	index.Add(e1, px.V(5, 5))
+	index.Add(e2, px.V(-12, 3))
+	index.Add(e3, px.V(25, 25))
+
+	e, ok := index.Nearest(px.V(0, 0), 10)
+	assert.True(t, ok)
+	assert.Equal(t, e1, e)
+
+	e, ok = index.Nearest(px.V(-8, 0), 10)
+	assert.True(t, ok)
+	assert.Equal(t, e2, e)
+
+	_, ok = index.Nearest(px.V(50, 50), 10)
+	assert.False(t, ok)
+
+	index.Reset(100)
+	_, ok = index.Nearest(px.V(0, 0), 10)
+	assert.False(t, ok)
+}
+
+func TestSpatialIndex_Reset(t *testing.T) {
+	w := ecs.NewWorld()
+	e := w.NewEntity()
+
+	index := newSpatialIndex(10)
+	for i := 0; i < 100; i++ {
+		index.Reset(10)
+		index.Add(e, px.V(float64(i)*10, 0))
+		assert.LessOrEqual(t, len(index.cells), 2)
+	}
+
+	index.Reset(10)
+	index.Reset(10)
+	assert.Empty(t, index.cells)
+}
+
+func TestUpdateIndex(t *testing.T) {
+	w := ecs.NewWorld()
+	builder := generic.NewMap1[px.Vec](&w)
+	e1 := builder.NewWith(&px.Vec{X: 5, Y: 5})
+
+	filter := generic.NewFilter1[px.Vec]()
+	position := func(p *px.Vec) px.Vec { return *p }
+	index := newSpatialIndex(10)
+	dirty := true
+
+	updateIndex(&w, &index, &dirty, filter, position, 10)
+	assert.False(t, dirty)
+	e, ok := index.Nearest(px.V(0, 0), 10)
+	assert.True(t, ok)
+	assert.Equal(t, e1, e)
+
+	e2 := builder.NewWith(&px.Vec{X: 1, Y: 1})
+	updateIndex(&w, &index, &dirty, filter, position, 10)
+	e, _ = index.Nearest(px.V(0, 0), 10)
+	assert.Equal(t, e1, e)
+
+	updateIndex(&w, &index, &dirty, filter, position, 20)
+	assert.Equal(t, 20.0, index.cellSize)
+	e, _ = index.Nearest(px.V(0, 0), 10)
+	assert.Equal(t, e2, e)
+}
@@ -6,9 +6,9 @@ import (
 	"reflect"
 
 	px "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/gopxl/pixel/v2/ext/text"
 	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
 	"github.com/mlange-42/arche/generic"
 )
@@ -21,67 +21,78 @@ import (
 // Details can be adjusted using the HideXxx fields.
 // Further, keys U, F, T, V and N can be used to toggle details during a running simulation.
 // The view can be scrolled using arrow keys or the mouse wheel.
+// Default keys are shared with [Inspector] and [Resources]. To use them in the same window,
+// put them into [window.Tabs], where only the selected tab receives user input, or remap keys.
+// Press F1 to show all shortcuts.
+// Keys can be remapped via the actions "systems.fields", "systems.scroll-up" etc. (see [window.Bindings]).
 type Systems struct {
-	HideUISystems bool // Hides UI systems.
-	HideFields    bool // Hides components fields.
-	HideTypes     bool // Hides field types.
-	HideValues    bool // Hides field values.
-	HideNames     bool // Hide field names of nested structs.
+	HideUISystems bool        // Hides UI systems.
+	HideFields    bool        // Hides components fields.
+	HideTypes     bool        // Hides field types.
+	HideValues    bool        // Hides field values.
+	HideNames     bool        // Hide field names of nested structs.
+	Font          *text.Atlas // Font for text. Optional, default the window's font.
 	scroll        int
 	systemsRes    generic.Resource[model.Systems]
 	text          *text.Text
-	helpText      *text.Text
+	keys          detailActions
+	uiKey         *window.Action
 }
 
 // Initialize the system
-func (i *Systems) Initialize(w *ecs.World, win *opengl.Window) {
+func (i *Systems) Initialize(w *ecs.World, ctx *window.Context) {
 	i.systemsRes = generic.NewResource[model.Systems](w)
 
-	i.text = text.New(px.V(0, 0), defaultFont)
-	i.helpText = text.New(px.V(0, 0), defaultFont)
+	i.text = text.New(px.V(0, 0), fontOr(i.Font, ctx))
 
 	i.text.AlignedTo(px.BottomRight)
-	i.helpText.AlignedTo(px.BottomRight)
+	i.text.Color = ctx.Theme.Foreground
 
-	fmt.Fprint(i.helpText, "Toggle [u]i systems, [f]ields, [t]ypes, [v]alues or [n]ames, scroll with arrows or mouse wheel.")
+	i.keys = newDetailActions(ctx.Bindings, "systems")
+	i.uiKey = ctx.Bindings.Register("systems.ui", px.KeyU, "Toggle UI systems")
+}
+
+// Shortcuts returns the drawer's keyboard and mouse shortcuts.
+func (i *Systems) Shortcuts() []window.Shortcut {
+	return append([]window.Shortcut{i.uiKey.Shortcut()}, i.keys.shortcuts()...)
 }
 
 // Update the drawer.
 func (i *Systems) Update(w *ecs.World) {}
 
 // UpdateInputs handles input events of the previous frame update.
-func (i *Systems) UpdateInputs(w *ecs.World, win *opengl.Window) {
-	if win.JustPressed(px.KeyF) {
+func (i *Systems) UpdateInputs(w *ecs.World, ctx *window.Context) {
+	if ctx.JustPressed(i.keys.fields.Key) {
 		i.HideFields = !i.HideFields
 		return
 	}
-	if win.JustPressed(px.KeyT) {
+	if ctx.JustPressed(i.keys.types.Key) {
 		i.HideTypes = !i.HideTypes
 		return
 	}
-	if win.JustPressed(px.KeyV) {
+	if ctx.JustPressed(i.keys.values.Key) {
 		i.HideValues = !i.HideValues
 		return
 	}
-	if win.JustPressed(px.KeyN) {
+	if ctx.JustPressed(i.keys.names.Key) {
 		i.HideNames = !i.HideNames
 		return
 	}
-	if win.JustPressed(px.KeyU) {
+	if ctx.JustPressed(i.uiKey.Key) {
 		i.HideUISystems = !i.HideUISystems
 		return
 	}
-	if win.JustPressed(px.KeyDown) {
+	if ctx.JustPressed(i.keys.scrollDown.Key) {
 		i.scroll++
 		return
 	}
-	if win.JustPressed(px.KeyUp) {
+	if ctx.JustPressed(i.keys.scrollUp.Key) {
 		if i.scroll > 0 {
 			i.scroll--
 		}
 		return
 	}
-	scr := win.MouseScroll()
+	scr := ctx.MouseScroll()
 	if scr.Y != 0 {
 		i.scroll -= int(scr.Y)
 		if i.scroll < 0 {
@@ -91,15 +102,13 @@ func (i *Systems) UpdateInputs(w *ecs.World, win *opengl.Window) {
 }
 
 // Draw the system
-func (i *Systems) Draw(w *ecs.World, win *opengl.Window) {
-	i.helpText.Draw(win, px.IM.Moved(px.V(10, 20)))
-
+func (i *Systems) Draw(w *ecs.World, ctx *window.Context) {
 	if !i.systemsRes.Has() {
 		return
 	}
 	systems := i.systemsRes.Get()
 
-	height := win.Canvas().Bounds().H()
+	height := ctx.Bounds.H()
 	x0 := 10.0
 	y0 := height - 10.0
 
@@ -141,7 +150,7 @@ func (i *Systems) Draw(w *ecs.World, win *opengl.Window) {
 	}
 
 	if i.HideUISystems {
-		i.text.Draw(win, px.IM.Moved(px.V(x0, y0)))
+		i.text.Draw(ctx, px.IM.Moved(px.V(x0, y0)))
 		return
 	}
 
@@ -172,7 +181,7 @@ func (i *Systems) Draw(w *ecs.World, win *opengl.Window) {
 		}
 	}
 
-	i.text.Draw(win, px.IM.Moved(px.V(x0, y0)))
+	i.text.Draw(ctx, px.IM.Moved(px.V(x0, y0)))
 }
 
 func (i *Systems) printField(w io.Writer, tp reflect.Type, field reflect.StructField, value reflect.Value) {
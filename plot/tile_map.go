@@ -0,0 +1,194 @@
+package plot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/gopxl/pixel/v2/ext/text"
+	"github.com/mlange-42/arche-model/observer"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+)
+
+const legendSwatchSize = 12.0
+
+// TileClass is a category of cells in a [TileMap].
+type TileClass struct {
+	Name  string      // Name of the class, shown in the legend.
+	Color color.Color // Color of the class. Optional if Frame is set.
+	Frame string      // Frame name of a sprite tile in the tile map's atlas. Optional, default use Color.
+}
+
+// TileMap drawer.
+//
+// Draws a map of categorical cells from a Matrix observer.
+// Cell values are converted to integers and used as index into the tile map's classes.
+// Each class is drawn either with a discrete color, or with a sprite tile from an [Atlas].
+// Cells with values outside the range of classes are not drawn.
+//
+// The map is scaled to the canvas extent, with preserved aspect ratio.
+// A legend showing the class names is drawn in the top left corner.
+// It can be toggled with L, remappable via the action "tilemap.legend" (see [window.Bindings]).
+type TileMap struct {
+	Scale      float64         // Spatial scaling: cell size in screen pixels. Optional, default auto.
+	Observer   observer.Matrix // Observer providing 2D matrix or grid data with integer class values.
+	Classes    []TileClass     // Classes for mapping values, with the cell value as index.
+	Atlas      *Atlas          // Atlas with sprite tiles. Required if any class has a Frame.
+	HideLegend bool            // Hides the legend. Optional, default false.
+	Font       *text.Atlas     // Font for text. Optional, default the window's font.
+	picture    *px.PictureData
+	batch      *px.Batch
+	colors     []color.RGBA
+	sprites    []*px.Sprite
+	hasSprites bool
+	drawer     imdraw.IMDraw
+	text       *text.Text
+	legend     *window.Action
+}
+
+// Initialize the drawer.
+func (t *TileMap) Initialize(w *ecs.World, ctx *window.Context) {
+	t.Observer.Initialize(w)
+
+	t.colors = make([]color.RGBA, len(t.Classes))
+	t.sprites = make([]*px.Sprite, len(t.Classes))
+	for i, c := range t.Classes {
+		if c.Frame != "" {
+			if t.Atlas == nil {
+				panic(fmt.Sprintf("tile class '%s' has a frame, but the tile map has no atlas", c.Name))
+			}
+			t.sprites[i] = t.Atlas.Sprite(c.Frame)
+			t.hasSprites = true
+			continue
+		}
+		if c.Color == nil {
+			panic(fmt.Sprintf("tile class '%s' has neither a color nor a frame", c.Name))
+		}
+		t.colors[i] = color.RGBAModel.Convert(c.Color).(color.RGBA)
+	}
+
+	width, height := t.Observer.Dims()
+	t.picture = px.MakePictureData(px.R(0, 0, float64(width), float64(height)))
+	if t.hasSprites {
+		t.batch = px.NewBatch(&px.TrianglesData{}, t.Atlas.Picture())
+	}
+
+	t.drawer = *imdraw.New(nil)
+	t.text = text.New(px.V(0, 0), fontOr(t.Font, ctx))
+	t.legend = ctx.Bindings.Register("tilemap.legend", px.KeyL, "Toggle legend")
+}
+
+// Update the drawer.
+func (t *TileMap) Update(w *ecs.World) {
+	t.Observer.Update(w)
+}
+
+// UpdateInputs handles input events of the previous frame update.
+func (t *TileMap) UpdateInputs(w *ecs.World, ctx *window.Context) {
+	if ctx.JustPressed(t.legend.Key) {
+		t.HideLegend = !t.HideLegend
+	}
+}
+
+// Draw the drawer.
+func (t *TileMap) Draw(w *ecs.World, ctx *window.Context) {
+	values := t.Observer.Values(w)
+	width := int(t.picture.Rect.W())
+
+	scale := t.Scale
+	if t.Scale <= 0 {
+		scale = window.Scale(ctx, t.picture.Rect.W(), t.picture.Rect.H())
+	}
+
+	if t.hasSprites {
+		t.batch.Clear()
+	}
+	for j, v := range values {
+		class := t.classIndex(v)
+		if class < 0 {
+			t.picture.Pix[j] = color.RGBA{}
+			continue
+		}
+		t.picture.Pix[j] = t.colors[class]
+		if sprite := t.sprites[class]; sprite != nil {
+			center := px.V(float64(j%width)+0.5, float64(j/width)+0.5).Scaled(scale)
+			sprite.Draw(t.batch, px.IM.Scaled(px.ZV, scale/spriteSize(sprite)).Moved(center))
+		}
+	}
+
+	sprite := px.NewSprite(t.picture, t.picture.Bounds())
+	sprite.Draw(ctx,
+		px.IM.Moved(px.V(t.picture.Rect.W()/2.0, t.picture.Rect.H()/2.0)).
+			Scaled(px.Vec{}, scale),
+	)
+	if t.hasSprites {
+		t.batch.Draw(ctx)
+	}
+
+	if !t.HideLegend {
+		t.drawLegend(ctx)
+	}
+}
+
+// Shortcuts returns the drawer's keyboard shortcuts.
+func (t *TileMap) Shortcuts() []window.Shortcut {
+	return []window.Shortcut{t.legend.Shortcut()}
+}
+
+// drawLegend draws the legend with the names of all classes.
+func (t *TileMap) drawLegend(ctx *window.Context) {
+	if len(t.Classes) == 0 {
+		return
+	}
+	lineHeight := math.Max(t.text.LineHeight, legendSwatchSize) + 4
+	maxWidth := 0.0
+	for _, c := range t.Classes {
+		maxWidth = math.Max(maxWidth, t.text.BoundsOf(c.Name).W())
+	}
+	topLeft := px.V(ctx.Bounds.Min.X+10, ctx.Bounds.Max.Y-10)
+	height := lineHeight*float64(len(t.Classes)) + 8
+
+	dr := &t.drawer
+	dr.Color = ctx.Theme.Overlay(180)
+	dr.Push(topLeft.Sub(px.V(0, height)), topLeft.Add(px.V(legendSwatchSize+maxWidth+22, 0)))
+	dr.Rectangle(0)
+
+	for i := range t.Classes {
+		min := topLeft.Add(px.V(6, -4-lineHeight*float64(i+1)+2))
+		if t.sprites[i] == nil {
+			dr.Color = t.colors[i]
+			dr.Push(min, min.Add(px.V(legendSwatchSize, legendSwatchSize)))
+			dr.Rectangle(0)
+		}
+	}
+	dr.Draw(ctx)
+	dr.Clear()
+
+	for i, c := range t.Classes {
+		min := topLeft.Add(px.V(6, -4-lineHeight*float64(i+1)+2))
+		if sprite := t.sprites[i]; sprite != nil {
+			center := min.Add(px.V(legendSwatchSize/2, legendSwatchSize/2))
+			sprite.Draw(ctx, px.IM.Scaled(px.ZV, legendSwatchSize/spriteSize(sprite)).Moved(center))
+		}
+		t.text.Clear()
+		t.text.Color = ctx.Theme.Foreground
+		fmt.Fprint(t.text, c.Name)
+		t.text.Draw(ctx, px.IM.Moved(min.Add(px.V(legendSwatchSize+6, 2))))
+	}
+}
+
+// classIndex returns the class index for a cell value, or -1 if there is no class for the value.
+func (t *TileMap) classIndex(v float64) int {
+	if math.IsNaN(v) || v < 0 || v >= float64(len(t.Classes)) {
+		return -1
+	}
+	return int(v)
+}
+
+// spriteSize returns the larger side length of a sprite's frame.
+func spriteSize(s *px.Sprite) float64 {
+	return math.Max(s.Frame().W(), s.Frame().H())
+}
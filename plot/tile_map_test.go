@@ -0,0 +1,142 @@
+package plot_test
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/plot"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/colornames"
+)
+
+func ExampleTileMap() {
+
+	// Create a new model.
+	m := model.New()
+
+	// Limit the the simulation speed.
+	m.TPS = 30
+
+	// Create a tile map with three land-use classes.
+	// See below for the implementation of the LandUseObserver.
+	m.AddUISystem(
+		(&window.Window{}).
+			With(&plot.TileMap{
+				Observer: &LandUseObserver{},
+				Classes: []plot.TileClass{
+					{Name: "Water", Color: colornames.Steelblue},
+					{Name: "Forest", Color: colornames.Forestgreen},
+					{Name: "Urban", Color: colornames.Gray},
+				},
+			}))
+
+	// Add a termination system that ends the simulation.
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+
+	// Run the simulation.
+	// Due to the use of the OpenGL UI system, the model must be run via [window.Run].
+	// Comment out the code line above, and uncomment the next line to run this example stand-alone.
+
+	// window.Run(m)
+
+	// Output:
+}
+
+func TestTileMap(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+
+	atlas := plot.NewAtlas(image.NewRGBA(image.Rect(0, 0, 32, 16)))
+	atlas.AddGrid(16, 16, "tree", "house")
+
+	m.AddUISystem(
+		(&window.Window{}).
+			With(&plot.TileMap{
+				Observer: &LandUseObserver{},
+				Atlas:    atlas,
+				Classes: []plot.TileClass{
+					{Name: "Water", Color: colornames.Steelblue},
+					{Name: "Forest", Frame: "tree"},
+				},
+			}))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+	m.Run()
+}
+
+func TestTileMap_Legend(t *testing.T) {
+	m := model.New()
+
+	input := keyInput{}
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), &input)
+
+	tiles := plot.TileMap{
+		Observer: &LandUseObserver{},
+		Classes:  []plot.TileClass{{Name: "Water", Color: colornames.Steelblue}},
+	}
+	tiles.Initialize(&m.World, ctx)
+	assert.Equal(t, []window.Shortcut{{Keys: "L", Description: "Toggle legend"}}, tiles.Shortcuts())
+
+	input.key = px.KeyL
+	tiles.UpdateInputs(&m.World, ctx)
+	assert.True(t, tiles.HideLegend)
+	tiles.UpdateInputs(&m.World, ctx)
+	assert.False(t, tiles.HideLegend)
+}
+
+func TestTileMap_Panic(t *testing.T) {
+	m := model.New()
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), nil)
+
+	tiles := plot.TileMap{
+		Observer: &LandUseObserver{},
+		Classes:  []plot.TileClass{{Name: "Forest", Frame: "tree"}},
+	}
+	assert.Panics(t, func() { tiles.Initialize(&m.World, ctx) })
+
+	tiles = plot.TileMap{
+		Observer: &LandUseObserver{},
+		Classes:  []plot.TileClass{{Name: "Water"}},
+	}
+	assert.Panics(t, func() { tiles.Initialize(&m.World, ctx) })
+}
+
+// Example observer, reporting a matrix of land-use classes 0, 1 and 2.
+type LandUseObserver struct {
+	cols   int
+	rows   int
+	values []float64
+}
+
+func (o *LandUseObserver) Initialize(w *ecs.World) {
+	o.cols = 60
+	o.rows = 40
+	o.values = make([]float64, o.cols*o.rows)
+}
+
+func (o *LandUseObserver) Update(w *ecs.World) {}
+
+func (o *LandUseObserver) Dims() (int, int) {
+	return o.cols, o.rows
+}
+
+func (o *LandUseObserver) Values(w *ecs.World) []float64 {
+	for idx := 0; idx < len(o.values); idx++ {
+		i := idx % o.cols
+		j := idx / o.cols
+		o.values[idx] = math.Floor(1.5 + 1.49*math.Sin(0.1*float64(i))*math.Cos(0.15*float64(j)))
+	}
+	return o.values
+}
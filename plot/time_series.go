@@ -4,15 +4,11 @@ import (
 	"fmt"
 	"image/color"
 
-	pixel "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/mlange-42/arche-model/observer"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/mlange-42/arche/ecs"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
-	"gonum.org/v1/plot/vg"
-	"gonum.org/v1/plot/vg/draw"
-	"gonum.org/v1/plot/vg/vgimg"
 )
 
 // TimeSeries plot drawer.
@@ -20,11 +16,13 @@ import (
 // Creates a line series per column of the observer.
 // Adds one row to the data per update.
 type TimeSeries struct {
-	Observer       observer.Row // Observer providing a data row per update.
-	Columns        []string     // Columns to show, by name. Optional, default all.
-	UpdateInterval int          // Interval for getting data from the the observer, in model ticks. Optional.
-	Labels         Labels       // Labels for plot and axes. Optional.
-	MaxRows        int          // Maximum number of rows to keep. Zero means unlimited. Optional.
+	Observer       observer.Row  // Observer providing a data row per update.
+	Columns        []string      // Columns to show, by name. Optional, default all.
+	UpdateInterval int           // Interval for getting data from the the observer, in model ticks. Optional.
+	Labels         Labels        // Labels for plot and axes. Optional.
+	MaxRows        int           // Maximum number of rows to keep. Zero means unlimited. Optional.
+	Palette        []color.Color // Colors of the series. Optional, default the palette of the window's theme.
+	CycleStyles    bool          // Vary the dash pattern with every series, for grayscale print. Optional, default only when colors repeat.
 
 	indices []int
 	headers []string
@@ -44,7 +42,7 @@ func (t *TimeSeries) append(x float64, values []float64) {
 }
 
 // Initialize the drawer.
-func (t *TimeSeries) Initialize(w *ecs.World, win *opengl.Window) {
+func (t *TimeSeries) Initialize(w *ecs.World, ctx *window.Context) {
 	t.Observer.Initialize(w)
 
 	t.headers = t.Observer.Header()
@@ -81,39 +79,37 @@ func (t *TimeSeries) Update(w *ecs.World) {
 }
 
 // UpdateInputs handles input events of the previous frame update.
-func (t *TimeSeries) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (t *TimeSeries) UpdateInputs(w *ecs.World, ctx *window.Context) {}
 
 // Draw the drawer.
-func (t *TimeSeries) Draw(w *ecs.World, win *opengl.Window) {
-	width := win.Canvas().Bounds().W()
-	height := win.Canvas().Bounds().H()
+func (t *TimeSeries) Draw(w *ecs.World, ctx *window.Context) {
+	renderPlot(t.buildPlot(ctx), ctx, t.scale)
+}
 
-	c := vgimg.New(vg.Points(width*t.scale)-10, vg.Points(height*t.scale)-10)
+// ExportVector writes the plot to a vector graphics file.
+func (t *TimeSeries) ExportVector(w *ecs.World, ctx *window.Context, path string) error {
+	return savePlot(t.buildPlot(ctx), ctx, t.scale, path)
+}
 
-	p := plot.New()
-	setLabels(p, t.Labels)
+// buildPlot creates the plot from the current data.
+func (t *TimeSeries) buildPlot(ctx *window.Context) *plot.Plot {
+	p := newPlot(ctx, t.Labels)
 
 	p.X.Tick.Marker = removeLastTicks{}
 
-	p.Legend = plot.NewLegend()
-	p.Legend.TextStyle.Font.Variant = "Mono"
+	p.Legend = newLegend(ctx)
+	style := newSeriesStyle(ctx, t.Palette, t.CycleStyles)
 
 	for i, idx := range t.indices {
 		lines, err := plotter.NewLine(t.series[idx])
 		if err != nil {
 			panic(err)
 		}
-		lines.Color = defaultColors[i%len(defaultColors)]
+		lines.Color = style.Color(i)
+		lines.Dashes = style.Dashes(i)
 		p.Add(lines)
 		p.Legend.Add(t.headers[idx], lines)
 	}
 
-	win.Clear(color.White)
-	p.Draw(draw.New(c))
-
-	img := c.Image()
-	picture := pixel.PictureDataFromImage(img)
-
-	sprite := pixel.NewSprite(picture, picture.Bounds())
-	sprite.Draw(win, pixel.IM.Moved(pixel.V(picture.Rect.W()/2.0+5, picture.Rect.H()/2.0+5)))
+	return p
 }
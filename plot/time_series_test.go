@@ -2,6 +2,7 @@ package plot_test
 
 import (
 	"math/rand"
+	"path/filepath"
 	"testing"
 
 	"github.com/mlange-42/arche-model/model"
@@ -74,6 +75,29 @@ func TestTimeSeries_PanicColumns(t *testing.T) {
 	assert.Panics(t, m.Run)
 }
 
+func TestTimeSeries_ExportVector(t *testing.T) {
+	dir := t.TempDir()
+
+	m := model.New()
+	m.TPS = 300
+
+	win := (&window.Window{ScreenshotDir: dir}).
+		With(&plot.TimeSeries{
+			Observer: &RowObserver{},
+		})
+	win.Screenshot()
+	m.AddUISystem(win)
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+	m.Run()
+
+	files, err := filepath.Glob(filepath.Join(dir, "Arche_*_1.svg"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(files))
+}
+
 // RowObserver to generate random time series.
 type RowObserver struct{}
 
@@ -85,3 +109,24 @@ func (o *RowObserver) Header() []string {
 func (o *RowObserver) Values(w *ecs.World) []float64 {
 	return []float64{rand.Float64(), rand.Float64() + 1, rand.Float64() + 2}
 }
+
+func TestTimeSeries_Theme(t *testing.T) {
+	for _, theme := range []*window.Theme{window.LightTheme(), window.HighContrastTheme()} {
+		m := model.New()
+		m.TPS = 300
+
+		m.AddUISystem((&window.Window{Theme: theme}).
+			With(
+				&plot.TimeSeries{
+					Observer: &RowObserver{},
+				},
+				&plot.Controls{},
+				&plot.PerfStats{},
+			))
+
+		m.AddSystem(&system.FixedTermination{
+			Steps: 100,
+		})
+		m.Run()
+	}
+}
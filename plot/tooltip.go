@@ -0,0 +1,139 @@
+package plot
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/gopxl/pixel/v2/ext/text"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+)
+
+// Tooltip drawer for showing a short summary of the entity under the mouse cursor.
+//
+// Shows the summary of the entity nearest to the mouse cursor, within Radius.
+// Entities are considered if they have a component of type P, and their position is obtained via the Position function.
+// Positions are interpreted in drawing coordinates, like for [Entities] and [Picker].
+// Entities are looked up in a spatial index, which is updated once per model tick, on demand.
+//
+// By default, the summary lists the entity's components with their values.
+// For full details of an entity, use the [Picker] together with the [Inspector].
+type Tooltip[P any] struct {
+	Position func(p *P) px.Vec                       // Function to get the position from a component. Required.
+	Summary  func(w *ecs.World, e ecs.Entity) string // Function to create the summary text of an entity. Optional, default components and their values.
+	Radius   float64                                 // Radius for finding the entity under the mouse, in pixels. Optional, default 10.
+	Font     *text.Atlas                             // Font for text. Optional, default the window's font.
+	filter   generic.Filter1[P]
+	posMap   generic.Map[P]
+	index    spatialIndex
+	dirty    bool
+	hovered  ecs.Entity
+	mouse    px.Vec
+	drawer   imdraw.IMDraw
+	text     *text.Text
+}
+
+// Initialize the drawer.
+func (t *Tooltip[P]) Initialize(w *ecs.World, ctx *window.Context) {
+	if t.Position == nil {
+		panic("tooltip requires a Position function")
+	}
+	if t.Summary == nil {
+		t.Summary = entitySummary
+	}
+	if t.Radius <= 0 {
+		t.Radius = 10
+	}
+
+	t.filter = *generic.NewFilter1[P]()
+	t.posMap = generic.NewMap[P](w)
+	t.index = newSpatialIndex(t.Radius)
+	t.dirty = true
+	t.hovered = ecs.Entity{}
+
+	t.drawer = *imdraw.New(nil)
+	t.text = text.New(px.V(0, 0), fontOr(t.Font, ctx))
+}
+
+// Update the drawer.
+func (t *Tooltip[P]) Update(w *ecs.World) {
+	t.dirty = true
+}
+
+// UpdateInputs handles input events of the previous frame update.
+func (t *Tooltip[P]) UpdateInputs(w *ecs.World, ctx *window.Context) {
+	pos := ctx.MousePosition()
+	t.mouse = ctx.Matrix.Project(pos)
+	if !ctx.Bounds.Contains(t.mouse) {
+		t.hovered = ecs.Entity{}
+		return
+	}
+
+	radius := t.Radius / matrixScale(ctx.Matrix)
+	updateIndex(w, &t.index, &t.dirty, &t.filter, t.Position, radius)
+	t.hovered, _ = t.index.Nearest(pos, radius)
+}
+
+// Draw the drawer.
+func (t *Tooltip[P]) Draw(w *ecs.World, ctx *window.Context) {
+	e := t.hovered
+	if e.IsZero() || !w.Alive(e) || !t.posMap.Has(e) {
+		return
+	}
+
+	t.text.Clear()
+	t.text.Color = ctx.Theme.Foreground
+	fmt.Fprint(t.text, t.Summary(w, e))
+	drawTextBox(ctx, &t.drawer, t.text, t.mouse)
+}
+
+// drawTextBox draws a text on an overlay box next to the mouse position, given in pixel coordinates.
+// The box is placed to the bottom right of the cursor, but kept inside the drawing area.
+func drawTextBox(ctx *window.Context, dr *imdraw.IMDraw, txt *text.Text, mouse px.Vec) {
+	bounds := txt.Bounds()
+
+	min := mouse.Add(px.V(12, -12-bounds.H()))
+	if min.X+bounds.W()+8 > ctx.Bounds.Max.X {
+		min.X = mouse.X - 12 - bounds.W() - 8
+	}
+	if min.Y < ctx.Bounds.Min.Y {
+		min.Y = mouse.Y + 12
+	}
+	box := px.R(min.X, min.Y, min.X+bounds.W()+8, min.Y+bounds.H()+8)
+
+	inv := invertMatrix(ctx.Matrix)
+	dr.SetMatrix(inv)
+	dr.Color = ctx.Theme.Overlay(200)
+	dr.Push(box.Min, box.Max)
+	dr.Rectangle(0)
+	dr.Draw(ctx)
+	dr.Clear()
+
+	txt.Draw(ctx, px.IM.Moved(px.V(box.Min.X+4-bounds.Min.X, box.Min.Y+4-bounds.Min.Y)).Chained(inv))
+}
+
+// Hovered returns the entity under the mouse cursor. It is the zero entity if there is none.
+func (t *Tooltip[P]) Hovered() ecs.Entity {
+	return t.hovered
+}
+
+// entitySummary lists the components of an entity with their values, one per line.
+func entitySummary(w *ecs.World, e ecs.Entity) string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "Entity %+v", e)
+
+	mask := w.Mask(e)
+	for _, id := range ecs.ComponentIDs(w) {
+		if !mask.Get(id) {
+			continue
+		}
+		tp, _ := ecs.ComponentInfo(w, id)
+		val := reflect.NewAt(tp.Type, w.Get(e, id)).Elem()
+		fmt.Fprintf(&b, "\n%s %+v", tp.Type.Name(), val.Interface())
+	}
+	return b.String()
+}
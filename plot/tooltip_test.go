@@ -0,0 +1,74 @@
+package plot_test
+
+import (
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/plot"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTooltip(t *testing.T) {
+	m := model.New()
+
+	builder := generic.NewMap1[Position](&m.World)
+	e1 := builder.NewWith(&Position{X: 100, Y: 100})
+	e2 := builder.NewWith(&Position{X: 110, Y: 100})
+
+	input := keyInput{}
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), &input)
+
+	tooltip := plot.Tooltip[Position]{
+		Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+	}
+	tooltip.Initialize(&m.World, ctx)
+
+	input.mouse = px.V(103, 101)
+	tooltip.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, e1, tooltip.Hovered())
+
+	input.mouse = px.V(108, 95)
+	tooltip.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, e2, tooltip.Hovered())
+
+	input.mouse = px.V(200, 100)
+	tooltip.UpdateInputs(&m.World, ctx)
+	assert.True(t, tooltip.Hovered().IsZero())
+
+	input.mouse = px.V(900, 100)
+	tooltip.UpdateInputs(&m.World, ctx)
+	assert.True(t, tooltip.Hovered().IsZero())
+}
+
+func TestTooltip_Draw(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+
+	builder := generic.NewMap2[Position, Name](&m.World)
+	for i := 0; i < 100; i++ {
+		builder.NewWith(&Position{X: float64(i%10) * 80, Y: float64(i/10) * 60}, &Name{Name: "Test"})
+	}
+
+	m.AddUISystem((&window.Window{}).
+		With(&plot.Tooltip[Position]{
+			Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+			Radius:   100,
+		}))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+	m.Run()
+
+	m = model.New()
+	m.AddUISystem((&window.Window{}).
+		With(&plot.Tooltip[Position]{}))
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	assert.Panics(t, m.Run)
+}
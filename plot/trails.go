@@ -0,0 +1,171 @@
+package plot
+
+import (
+	px "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/mazznoer/colorgrad"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+)
+
+// TrailColor determines how the trails of a [Trails] drawer are colored.
+type TrailColor uint8
+
+const (
+	// TrailColorEntity colors trails by entity, cycling through the series colors of the theme.
+	TrailColorEntity TrailColor = iota
+	// TrailColorSpeed colors trails by the speed of the entity, i.e. the distance moved per model tick.
+	TrailColorSpeed
+)
+
+// Trails drawer for showing where entities have been.
+//
+// Records the last positions of entities with a component of type P, once per model tick,
+// and draws them as polylines that fade out towards older positions.
+// Trails of entities that were removed, or that lost their position component, are dropped.
+// Positions are interpreted in drawing coordinates, like for [Entities].
+// Trails are drawn in the order in which their entities were first recorded.
+//
+// Trails can be shown or hidden with P, remappable via the action "trails.toggle" (see [window.Bindings]).
+// Positions are recorded also while trails are hidden.
+type Trails[P any] struct {
+	Position func(p *P) px.Vec  // Function to get the position from a component. Required.
+	Length   int                // Number of recorded positions per entity. Optional, default 50.
+	Color    TrailColor         // Coloring mode. Optional, default TrailColorEntity.
+	Colors   colorgrad.Gradient // Colors for TrailColorSpeed. Optional, default viridis.
+	MaxSpeed float64            // Speed mapped to the end of Colors. Optional, default the maximum speed of all trails.
+	Width    float64            // Line width in pixels. Optional, default 1.
+	Hidden   bool               // Whether the trails are hidden.
+	filter   generic.Filter1[P]
+	trails   map[ecs.Entity]*trail
+	entities []ecs.Entity // Entities with trails, in drawing order.
+	update   int
+	toggle   *window.Action
+	drawer   imdraw.IMDraw
+}
+
+// trail of a single entity.
+type trail struct {
+	positions ringBuffer[px.Vec]
+	update    int
+}
+
+// Initialize the drawer.
+func (t *Trails[P]) Initialize(w *ecs.World, ctx *window.Context) {
+	if t.Position == nil {
+		panic("trails drawer requires a Position function")
+	}
+	if t.Length <= 1 {
+		t.Length = 50
+	}
+	if t.Colors == (colorgrad.Gradient{}) {
+		t.Colors = colorgrad.Viridis()
+	}
+	if t.Width <= 0 {
+		t.Width = 1
+	}
+
+	t.filter = *generic.NewFilter1[P]()
+	t.trails = map[ecs.Entity]*trail{}
+	t.entities = t.entities[:0]
+	t.update = 0
+	t.toggle = ctx.Bindings.Register("trails.toggle", px.KeyP, "Show or hide trails")
+	t.drawer = *imdraw.New(nil)
+}
+
+// Update the drawer.
+func (t *Trails[P]) Update(w *ecs.World) {
+	t.update++
+
+	query := t.filter.Query(w)
+	for query.Next() {
+		e := query.Entity()
+		tr, ok := t.trails[e]
+		if !ok {
+			tr = &trail{positions: newRingBuffer[px.Vec](t.Length)}
+			t.trails[e] = tr
+			t.entities = append(t.entities, e)
+		}
+		tr.positions.Add(t.Position(query.Get()))
+		tr.update = t.update
+	}
+
+	kept := t.entities[:0]
+	for _, e := range t.entities {
+		if t.trails[e].update != t.update {
+			delete(t.trails, e)
+			continue
+		}
+		kept = append(kept, e)
+	}
+	t.entities = kept
+}
+
+// UpdateInputs handles input events of the previous frame update.
+func (t *Trails[P]) UpdateInputs(w *ecs.World, ctx *window.Context) {
+	if ctx.JustPressed(t.toggle.Key) {
+		t.Hidden = !t.Hidden
+	}
+}
+
+// Draw the drawer.
+func (t *Trails[P]) Draw(w *ecs.World, ctx *window.Context) {
+	if t.Hidden {
+		return
+	}
+
+	maxSpeed := t.MaxSpeed
+	if t.Color == TrailColorSpeed && maxSpeed <= 0 {
+		maxSpeed = t.maxSpeed()
+	}
+
+	dr := &t.drawer
+	width := t.Width / matrixScale(ctx.Matrix)
+	for _, e := range t.entities {
+		tr := t.trails[e]
+		n := tr.positions.Len()
+		if n < 2 {
+			continue
+		}
+		col := px.ToRGBA(ctx.Theme.SeriesColor(int(e.ID())))
+		for i := 0; i < n; i++ {
+			pos := tr.positions.Get(i)
+			if t.Color == TrailColorSpeed {
+				col = t.speedColor(tr.positions.Get(max(i, 1)).Sub(tr.positions.Get(max(i, 1)-1)).Len(), maxSpeed)
+			}
+			dr.Color = col.Scaled(float64(i+1) / float64(n))
+			dr.Push(pos)
+		}
+		dr.Line(width)
+	}
+	dr.Draw(ctx)
+	dr.Clear()
+}
+
+// Shortcuts returns the drawer's keyboard shortcuts.
+func (t *Trails[P]) Shortcuts() []window.Shortcut {
+	return []window.Shortcut{t.toggle.Shortcut()}
+}
+
+// maxSpeed returns the maximum speed over all trails.
+func (t *Trails[P]) maxSpeed() float64 {
+	maxSpeed := 0.0
+	for _, tr := range t.trails {
+		for i := 1; i < tr.positions.Len(); i++ {
+			speed := tr.positions.Get(i).Sub(tr.positions.Get(i - 1)).Len()
+			if speed > maxSpeed {
+				maxSpeed = speed
+			}
+		}
+	}
+	return maxSpeed
+}
+
+// speedColor maps a speed to a color.
+func (t *Trails[P]) speedColor(speed, maxSpeed float64) px.RGBA {
+	if maxSpeed <= 0 {
+		return px.ToRGBA(t.Colors.At(0))
+	}
+	return px.ToRGBA(t.Colors.At(speed / maxSpeed))
+}
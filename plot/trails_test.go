@@ -0,0 +1,189 @@
+package plot_test
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/plot"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleTrails() {
+	// Create a new model.
+	m := model.New()
+
+	// Limit the the simulation speed.
+	m.TPS = 30
+
+	// Create some entities.
+	builder := generic.NewMap2[Position, Velocity](&m.World)
+	for i := 0; i < 20; i++ {
+		builder.NewWith(
+			&Position{X: rand.Float64() * 600, Y: rand.Float64() * 400},
+			&Velocity{X: rand.NormFloat64(), Y: rand.NormFloat64()},
+		)
+	}
+
+	// Add a system that moves the entities.
+	// See below for the implementation of the MoveSystem.
+	m.AddSystem(&MoveSystem{})
+
+	// Draw trails and entities, with trails colored by speed.
+	position := func(p *Position) px.Vec { return px.V(p.X, p.Y) }
+	m.AddUISystem((&window.Window{}).
+		With((&window.Camera{}).With(
+			&plot.Trails[Position]{
+				Position: position,
+				Color:    plot.TrailColorSpeed,
+			},
+			&plot.Entities[Position]{
+				Position: position,
+			},
+		)))
+
+	// Add a termination system that ends the simulation.
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+
+	// Run the simulation.
+	// Due to the use of the OpenGL UI system, the model must be run via [window.Run].
+	// Comment out the code line above, and uncomment the next line to run this example stand-alone.
+
+	// window.Run(m)
+
+	// Output:
+}
+
+func TestTrails(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+
+	builder := generic.NewMap2[Position, Velocity](&m.World)
+	for i := 0; i < 20; i++ {
+		builder.NewWith(
+			&Position{X: rand.Float64() * 600, Y: rand.Float64() * 400},
+			&Velocity{X: rand.NormFloat64(), Y: rand.NormFloat64()},
+		)
+	}
+	m.AddSystem(&MoveSystem{RemoveAt: 50})
+
+	position := func(p *Position) px.Vec { return px.V(p.X, p.Y) }
+	m.AddUISystem((&window.Window{}).
+		With(
+			&plot.Trails[Position]{
+				Position: position,
+				Length:   20,
+			},
+			&plot.Trails[Position]{
+				Position: position,
+				Color:    plot.TrailColorSpeed,
+				MaxSpeed: 2,
+				Width:    3,
+			},
+		))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+	m.Run()
+}
+
+func TestTrails_Order(t *testing.T) {
+	w := ecs.NewWorld()
+	builder := generic.NewMap1[Position](&w)
+	for i := 0; i < 10; i++ {
+		builder.NewWith(&Position{X: 10, Y: 50})
+	}
+
+	target := window.NewOffscreen(px.R(0, 0, 100, 100))
+	ctx := window.NewContext(target, target.Bounds(), nil)
+	trails := plot.Trails[Position]{
+		Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+		Width:    3,
+	}
+	trails.Initialize(&w, ctx)
+	trails.Update(&w)
+
+	query := generic.NewFilter1[Position]().Query(&w)
+	for query.Next() {
+		query.Get().X = 90
+	}
+	trails.Update(&w)
+
+	// All trails overlap, so the image depends on the drawing order.
+	var first *image.RGBA
+	for i := 0; i < 20; i++ {
+		target.Clear(color.Black)
+		trails.Draw(&w, ctx)
+		img := target.Image()
+		if first == nil {
+			first = img
+			continue
+		}
+		assert.Equal(t, first.Pix, img.Pix)
+	}
+}
+
+func TestTrails_Toggle(t *testing.T) {
+	m := model.New()
+
+	input := keyInput{}
+	ctx := window.NewContext(nil, px.R(0, 0, 800, 600), &input)
+
+	trails := plot.Trails[Position]{
+		Position: func(p *Position) px.Vec { return px.V(p.X, p.Y) },
+	}
+	trails.Initialize(&m.World, ctx)
+	assert.Equal(t, []window.Shortcut{{Keys: "P", Description: "Show or hide trails"}}, trails.Shortcuts())
+
+	input.key = px.KeyP
+	trails.UpdateInputs(&m.World, ctx)
+	assert.True(t, trails.Hidden)
+	trails.UpdateInputs(&m.World, ctx)
+	assert.False(t, trails.Hidden)
+
+	trails = plot.Trails[Position]{}
+	assert.Panics(t, func() { trails.Initialize(&m.World, ctx) })
+}
+
+// MoveSystem moves entities by their velocity.
+// Removes half of the entities at tick RemoveAt, if it is greater than zero.
+type MoveSystem struct {
+	RemoveAt int
+	filter   generic.Filter2[Position, Velocity]
+	tick     int
+}
+
+func (s *MoveSystem) Initialize(w *ecs.World) {
+	s.filter = *generic.NewFilter2[Position, Velocity]()
+}
+
+func (s *MoveSystem) Update(w *ecs.World) {
+	s.tick++
+	toRemove := []ecs.Entity{}
+	query := s.filter.Query(w)
+	for i := 0; query.Next(); i++ {
+		pos, vel := query.Get()
+		pos.X += vel.X
+		pos.Y += vel.Y
+		if s.tick == s.RemoveAt && i%2 == 0 {
+			toRemove = append(toRemove, query.Entity())
+		}
+	}
+	for _, e := range toRemove {
+		w.RemoveEntity(e)
+	}
+}
+
+func (s *MoveSystem) Finalize(w *ecs.World) {}
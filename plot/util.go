@@ -2,31 +2,23 @@ package plot
 
 import (
 	"fmt"
-	"image/color"
 	"math"
 
+	px "github.com/gopxl/pixel/v2"
 	"github.com/gopxl/pixel/v2/ext/text"
 	"github.com/mlange-42/arche-model/observer"
-	"golang.org/x/image/colornames"
-	"golang.org/x/image/font/basicfont"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
 	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
 	"gonum.org/v1/plot/vg/vgimg"
 )
 
-var defaultFont = text.NewAtlas(basicfont.Face7x13, text.ASCII)
-
 var preferredTicks = []float64{1, 2, 5, 10}
 var preferredTps = []float64{0, 1, 2, 3, 4, 5, 7, 10, 15, 20, 30, 40, 50, 60, 80, 100, 120, 150, 200, 250, 500, 750, 1000, 2000, 5000, 10000}
 
-var defaultColors = []color.Color{
-	colornames.Blue,
-	colornames.Orange,
-	colornames.Green,
-	colornames.Purple,
-	colornames.Red,
-	colornames.Turquoise,
-}
-
 // Labels for plots.
 type Labels struct {
 	Title string // Plot title
@@ -34,6 +26,96 @@ type Labels struct {
 	Y     string // Y axis label
 }
 
+// detailActions are the key bindings of drawers that list items with their fields.
+type detailActions struct {
+	fields     *window.Action
+	types      *window.Action
+	values     *window.Action
+	names      *window.Action
+	scrollUp   *window.Action
+	scrollDown *window.Action
+}
+
+// newDetailActions registers the actions of a detail drawer, with names prefixed by the given prefix.
+func newDetailActions(b *window.Bindings, prefix string) detailActions {
+	return detailActions{
+		fields:     b.Register(prefix+".fields", px.KeyF, "Toggle fields"),
+		types:      b.Register(prefix+".types", px.KeyT, "Toggle field types"),
+		values:     b.Register(prefix+".values", px.KeyV, "Toggle field values"),
+		names:      b.Register(prefix+".names", px.KeyN, "Toggle field names of nested structs"),
+		scrollUp:   b.Register(prefix+".scroll-up", px.KeyUp, "Scroll up"),
+		scrollDown: b.Register(prefix+".scroll-down", px.KeyDown, "Scroll down"),
+	}
+}
+
+// shortcuts returns descriptions of the actions, including mouse wheel scrolling.
+func (a *detailActions) shortcuts() []window.Shortcut {
+	return []window.Shortcut{
+		a.fields.Shortcut(),
+		a.types.Shortcut(),
+		a.values.Shortcut(),
+		a.names.Shortcut(),
+		a.scrollUp.Shortcut(),
+		a.scrollDown.Shortcut(),
+		{Keys: "Mouse wheel", Description: "Scroll"},
+	}
+}
+
+// matrixScale returns the scale factor of a transformation matrix.
+func matrixScale(m px.Matrix) float64 {
+	return m.Project(px.V(1, 0)).Sub(m.Project(px.ZV)).Len()
+}
+
+// visibleRect returns the region of drawing coordinates that is visible in the given context,
+// i.e. the bounds transformed by the inverse of the context's matrix.
+func visibleRect(ctx *window.Context) px.Rect {
+	b := ctx.Bounds
+	corners := []px.Vec{
+		ctx.Matrix.Unproject(b.Min),
+		ctx.Matrix.Unproject(b.Max),
+		ctx.Matrix.Unproject(px.V(b.Min.X, b.Max.Y)),
+		ctx.Matrix.Unproject(px.V(b.Max.X, b.Min.Y)),
+	}
+	r := px.Rect{Min: corners[0], Max: corners[0]}
+	for _, c := range corners[1:] {
+		r.Min = px.V(math.Min(r.Min.X, c.X), math.Min(r.Min.Y, c.Y))
+		r.Max = px.V(math.Max(r.Max.X, c.X), math.Max(r.Max.Y, c.Y))
+	}
+	return r
+}
+
+// invertMatrix returns the inverse of a transformation matrix.
+// It is used to draw in pixel coordinates, in contexts with a matrix for drawing coordinates.
+func invertMatrix(m px.Matrix) px.Matrix {
+	o := m.Unproject(px.ZV)
+	x := m.Unproject(px.V(1, 0)).Sub(o)
+	y := m.Unproject(px.V(0, 1)).Sub(o)
+	return px.Matrix{x.X, x.Y, y.X, y.Y, o.X, o.Y}
+}
+
+// updateIndex re-builds a spatial index of the entities matching a filter,
+// if it is marked as dirty or if the cell size has changed.
+// Clears the dirty flag.
+func updateIndex[P any](w *ecs.World, index *spatialIndex, dirty *bool, filter *generic.Filter1[P], position func(p *P) px.Vec, cellSize float64) {
+	if !*dirty && cellSize == index.cellSize {
+		return
+	}
+	index.Reset(cellSize)
+	query := filter.Query(w)
+	for query.Next() {
+		index.Add(query.Entity(), position(query.Get()))
+	}
+	*dirty = false
+}
+
+// fontOr returns the given font, or the font of the context if it is nil.
+func fontOr(font *text.Atlas, ctx *window.Context) *text.Atlas {
+	if font != nil {
+		return font
+	}
+	return ctx.Font
+}
+
 // Get the index of an element in a slice.
 func find[T comparable](sl []T, value T) (int, bool) {
 	for i, v := range sl {
@@ -83,6 +165,53 @@ func calcTps(curr float64, increase bool) float64 {
 	return 0
 }
 
+// renderPlot renders a plot into the bounds of the drawing context.
+func renderPlot(p *plot.Plot, ctx *window.Context, scale float64) {
+	width, height := ctx.Bounds.W(), ctx.Bounds.H()
+	c := vgimg.New(vg.Points(width*scale)-10, vg.Points(height*scale)-10)
+
+	ctx.Clear(ctx.Theme.Background)
+	p.Draw(draw.New(c))
+
+	img := c.Image()
+	picture := px.PictureDataFromImage(img)
+
+	sprite := px.NewSprite(picture, picture.Bounds())
+	sprite.Draw(ctx, px.IM.Moved(px.V(picture.Rect.W()/2.0+5, picture.Rect.H()/2.0+5)))
+}
+
+// savePlot saves a plot to a file, with the size of the drawing context.
+// The format is determined by the file extension.
+func savePlot(p *plot.Plot, ctx *window.Context, scale float64, path string) error {
+	width, height := ctx.Bounds.W(), ctx.Bounds.H()
+	return p.Save(vg.Points(width*scale)-10, vg.Points(height*scale)-10, path)
+}
+
+// newPlot creates a plot with the given labels, using the colors of the context's theme.
+func newPlot(ctx *window.Context, l Labels) *plot.Plot {
+	p := plot.New()
+	setLabels(p, l)
+
+	theme := ctx.Theme
+	p.BackgroundColor = theme.Background
+	p.Title.TextStyle.Color = theme.Foreground
+	for _, axis := range []*plot.Axis{&p.X, &p.Y} {
+		axis.Color = theme.Foreground
+		axis.Label.TextStyle.Color = theme.Foreground
+		axis.Tick.Color = theme.Foreground
+		axis.Tick.Label.Color = theme.Foreground
+	}
+	return p
+}
+
+// newLegend creates a plot legend, using the colors of the context's theme.
+func newLegend(ctx *window.Context) plot.Legend {
+	legend := plot.NewLegend()
+	legend.TextStyle.Font.Variant = "Mono"
+	legend.TextStyle.Color = ctx.Theme.Foreground
+	return legend
+}
+
 func setLabels(p *plot.Plot, l Labels) {
 	p.Title.Text = l.Title
 	p.Title.TextStyle.Font.Size = 16
@@ -3,6 +3,8 @@ package plot
 import (
 	"testing"
 
+	px "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-pixel/window"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -63,3 +65,18 @@ func TestCalcTps(t *testing.T) {
 	tps = calcTps(12345, true)
 	assert.Equal(t, 12345.0, tps)
 }
+
+func TestVisibleRect(t *testing.T) {
+	ctx := window.NewContext(nil, px.R(0, 0, 200, 100), nil)
+	assert.Equal(t, px.R(0, 0, 200, 100), visibleRect(ctx))
+
+	ctx.Matrix = px.IM.Scaled(px.ZV, 2).Moved(px.V(100, 0))
+	assert.Equal(t, px.R(-50, 0, 50, 50), visibleRect(ctx))
+
+	assert.Equal(t, 2.0, matrixScale(ctx.Matrix))
+
+	inv := invertMatrix(ctx.Matrix)
+	assert.Equal(t, px.V(-50, 0), inv.Project(px.V(0, 0)))
+	assert.Equal(t, px.V(50, 50), inv.Project(px.V(200, 100)))
+	assert.Equal(t, px.V(3, 4), ctx.Matrix.Project(inv.Project(px.V(3, 4))))
+}
@@ -0,0 +1,222 @@
+package window
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/jpeg"
+	"io"
+)
+
+var errAviFrameSize = errors.New("frame size does not match video size")
+
+const (
+	aviHasIndex = 0x10 // AVIF_HASINDEX
+	aviKeyFrame = 0x10 // AVIIF_KEYFRAME
+)
+
+// aviWriter writes Motion-JPEG frames into an AVI container.
+//
+// Frames are streamed to the underlying writer.
+// Sizes and frame counts in the headers are patched in [aviWriter.Close].
+type aviWriter struct {
+	writer     io.WriteSeeker
+	width      int
+	height     int
+	quality    int
+	frames     uint32
+	maxSize    uint32
+	index      []aviIndexEntry
+	buffer     bytes.Buffer
+	riffSize   int64 // Offset of the RIFF size field.
+	avihFrames int64 // Offset of the total frames field in the main header.
+	avihBuffer int64 // Offset of the suggested buffer size in the main header.
+	strhLength int64 // Offset of the length field in the stream header.
+	strhBuffer int64 // Offset of the suggested buffer size in the stream header.
+	moviSize   int64 // Offset of the movi list size field.
+	moviStart  int64 // Offset of the movi list type, as reference for index entries.
+	pos        int64
+	err        error
+}
+
+type aviIndexEntry struct {
+	offset uint32
+	size   uint32
+}
+
+// newAviWriter creates a new aviWriter and writes the file headers.
+func newAviWriter(w io.WriteSeeker, width, height, fps, quality int) (*aviWriter, error) {
+	a := aviWriter{
+		writer:  w,
+		width:   width,
+		height:  height,
+		quality: quality,
+	}
+
+	a.riffSize = a.chunk("RIFF", 0)
+	a.fourCC("AVI ")
+
+	hdrlSize := a.chunk("LIST", 0)
+	a.fourCC("hdrl")
+
+	a.chunk("avih", 56)
+	a.u32(uint32(1_000_000 / fps)) // Micro seconds per frame
+	a.u32(0)                       // Max bytes per second
+	a.u32(0)                       // Padding granularity
+	a.u32(aviHasIndex)             // Flags
+	a.avihFrames = a.u32(0)        // Total frames
+	a.u32(0)                       // Initial frames
+	a.u32(1)                       // Streams
+	a.avihBuffer = a.u32(0)        // Suggested buffer size
+	a.u32(uint32(width))
+	a.u32(uint32(height))
+	a.u32(0) // Reserved
+	a.u32(0)
+	a.u32(0)
+	a.u32(0)
+
+	strlSize := a.chunk("LIST", 0)
+	a.fourCC("strl")
+
+	a.chunk("strh", 56)
+	a.fourCC("vids")
+	a.fourCC("MJPG")
+	a.u32(0)                // Flags
+	a.u16(0)                // Priority
+	a.u16(0)                // Language
+	a.u32(0)                // Initial frames
+	a.u32(1)                // Scale
+	a.u32(uint32(fps))      // Rate
+	a.u32(0)                // Start
+	a.strhLength = a.u32(0) // Length
+	a.strhBuffer = a.u32(0) // Suggested buffer size
+	a.u32(0xffffffff)       // Quality
+	a.u32(0)                // Sample size
+	a.u16(0)                // Frame rectangle
+	a.u16(0)
+	a.u16(uint16(width))
+	a.u16(uint16(height))
+
+	a.chunk("strf", 40)
+	a.u32(40) // Header size
+	a.u32(uint32(width))
+	a.u32(uint32(height))
+	a.u16(1)  // Planes
+	a.u16(24) // Bit count
+	a.fourCC("MJPG")
+	a.u32(uint32(width * height * 3)) // Image size
+	a.u32(0)                          // Pixels per meter X
+	a.u32(0)                          // Pixels per meter Y
+	a.u32(0)                          // Colors used
+	a.u32(0)                          // Colors important
+
+	a.patchSize(strlSize)
+	a.patchSize(hdrlSize)
+
+	a.moviSize = a.chunk("LIST", 0)
+	a.moviStart = a.offset()
+	a.fourCC("movi")
+
+	return &a, a.err
+}
+
+// WriteFrame encodes an image as JPEG and appends it to the video.
+func (a *aviWriter) WriteFrame(img image.Image) error {
+	if b := img.Bounds(); b.Dx() != a.width || b.Dy() != a.height {
+		return errAviFrameSize
+	}
+	a.buffer.Reset()
+	if err := jpeg.Encode(&a.buffer, img, &jpeg.Options{Quality: a.quality}); err != nil {
+		return err
+	}
+	size := uint32(a.buffer.Len())
+
+	start := a.chunk("00dc", size)
+	a.write(a.buffer.Bytes())
+	if size%2 == 1 {
+		a.write([]byte{0})
+	}
+
+	a.index = append(a.index, aviIndexEntry{offset: uint32(start - 4 - a.moviStart), size: size})
+	a.frames++
+	if size > a.maxSize {
+		a.maxSize = size
+	}
+	return a.err
+}
+
+// Close writes the index and patches the headers.
+// Does not close the underlying writer.
+func (a *aviWriter) Close() error {
+	a.patchSize(a.moviSize)
+
+	a.chunk("idx1", uint32(16*len(a.index)))
+	for _, e := range a.index {
+		a.fourCC("00dc")
+		a.u32(aviKeyFrame)
+		a.u32(e.offset)
+		a.u32(e.size)
+	}
+
+	a.patchSize(a.riffSize)
+	a.patch(a.avihFrames, a.frames)
+	a.patch(a.strhLength, a.frames)
+	a.patch(a.avihBuffer, a.maxSize)
+	a.patch(a.strhBuffer, a.maxSize)
+
+	return a.err
+}
+
+// chunk writes a chunk header and returns the offset of its size field.
+func (a *aviWriter) chunk(id string, size uint32) int64 {
+	a.fourCC(id)
+	return a.u32(size)
+}
+
+// patchSize sets the size field at the given offset to the number of bytes written after it.
+func (a *aviWriter) patchSize(at int64) {
+	a.patch(at, uint32(a.offset()-at-4))
+}
+
+// patch overwrites the value at the given offset and returns to the end of the stream.
+func (a *aviWriter) patch(at int64, value uint32) {
+	if a.err != nil {
+		return
+	}
+	end := a.pos
+	if a.pos, a.err = a.writer.Seek(at, io.SeekStart); a.err != nil {
+		return
+	}
+	a.u32(value)
+	if a.err == nil {
+		a.pos, a.err = a.writer.Seek(end, io.SeekStart)
+	}
+}
+
+func (a *aviWriter) fourCC(id string) {
+	a.write([]byte(id))
+}
+
+func (a *aviWriter) u32(v uint32) int64 {
+	at := a.offset()
+	a.write(binary.LittleEndian.AppendUint32(nil, v))
+	return at
+}
+
+func (a *aviWriter) u16(v uint16) {
+	a.write(binary.LittleEndian.AppendUint16(nil, v))
+}
+
+func (a *aviWriter) write(b []byte) {
+	if a.err != nil {
+		return
+	}
+	var n int
+	n, a.err = a.writer.Write(b)
+	a.pos += int64(n)
+}
+
+func (a *aviWriter) offset() int64 {
+	return a.pos
+}
@@ -0,0 +1,44 @@
+package window
+
+import (
+	"encoding/binary"
+	"image"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAviWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.avi")
+	file, err := os.Create(path)
+	assert.Nil(t, err)
+
+	avi, err := newAviWriter(file, 40, 30, 25, 90)
+	assert.Nil(t, err)
+
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, avi.WriteFrame(img))
+	}
+	assert.Equal(t, errAviFrameSize, avi.WriteFrame(image.NewRGBA(image.Rect(0, 0, 10, 10))))
+
+	assert.Nil(t, avi.Close())
+	assert.Nil(t, file.Close())
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "RIFF", string(data[0:4]))
+	assert.Equal(t, uint32(len(data)-8), binary.LittleEndian.Uint32(data[4:8]))
+	assert.Equal(t, "AVI ", string(data[8:12]))
+	assert.Equal(t, uint32(3), binary.LittleEndian.Uint32(data[avi.avihFrames:]))
+	assert.Equal(t, uint32(3), binary.LittleEndian.Uint32(data[avi.strhLength:]))
+	assert.Equal(t, "movi", string(data[avi.moviStart:avi.moviStart+4]))
+
+	idx := len(data) - 3*16 - 8
+	assert.Equal(t, "idx1", string(data[idx:idx+4]))
+	first := int64(binary.LittleEndian.Uint32(data[idx+16:]))
+	assert.Equal(t, "00dc", string(data[avi.moviStart+first:avi.moviStart+first+4]))
+}
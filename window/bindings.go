@@ -0,0 +1,186 @@
+package window
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	pixel "github.com/gopxl/pixel/v2"
+)
+
+// Action is a named input action of a [Drawer], bound to a key or mouse button.
+//
+// Actions are created with [Bindings.Register], typically in a drawer's Initialize method.
+// Check for the action's key like any other button:
+//
+//	if ctx.JustPressed(action.Key) { ... }
+//
+// Actions bound to [pixel.UnknownButton] are unbound, see [Action.Bound].
+type Action struct {
+	Name        string       // Name of the action, like "controls.pause".
+	Description string       // Short description of the action.
+	Default     pixel.Button // Default key of the action.
+	Key         pixel.Button // Current key of the action.
+}
+
+// Bound returns whether the action is bound to a key, i.e. whether its key is not [pixel.UnknownButton].
+// Unbound actions can't be triggered, and their key must not be checked for input.
+func (a *Action) Bound() bool {
+	return a.Key != pixel.UnknownButton
+}
+
+// Shortcut returns a description of the action for help displays, with the action's current key.
+func (a *Action) Shortcut() Shortcut {
+	return Shortcut{Keys: a.Key.String(), Description: a.Description}
+}
+
+// Shortcut describes a keyboard or mouse shortcut of a [Drawer], for help displays.
+type Shortcut struct {
+	Keys        string // Keys or mouse interactions, like "F" or "Mouse wheel".
+	Description string // What the shortcut does.
+}
+
+// Describer is an optional interface for a [Drawer], describing its keyboard and mouse shortcuts.
+// Shortcuts are shown in the help overlay of the [Window].
+type Describer interface {
+	Shortcuts() []Shortcut
+}
+
+// Bindings is a registry of input actions, shared by all drawers of a [Window].
+//
+// Drawers register named actions with default keys.
+// Users can remap keys by action name, from code or from a JSON file.
+// Multiple instances of the same drawer type register actions of the same name,
+// which are remapped together.
+type Bindings struct {
+	actions   []*Action
+	overrides map[string]pixel.Button
+}
+
+// NewBindings creates a new, empty key bindings registry.
+func NewBindings() *Bindings {
+	return &Bindings{
+		overrides: map[string]pixel.Button{},
+	}
+}
+
+// Register an action with a default key.
+// If the action's key was remapped before, the remapped key is used.
+func (b *Bindings) Register(name string, key pixel.Button, description string) *Action {
+	a := &Action{
+		Name:        name,
+		Description: description,
+		Default:     key,
+		Key:         key,
+	}
+	if k, ok := b.overrides[name]; ok {
+		a.Key = k
+	}
+	b.actions = append(b.actions, a)
+	return a
+}
+
+// Set the key of the action with the given name.
+// Applies to already registered actions as well as to actions registered later.
+func (b *Bindings) Set(name string, key pixel.Button) {
+	b.overrides[name] = key
+	for _, a := range b.actions {
+		if a.Name == name {
+			a.Key = key
+		}
+	}
+}
+
+// Load key bindings from a JSON file.
+// The file contains an object that maps action names to key names, like:
+//
+//	{
+//	  "controls.faster": "Up",
+//	  "controls.slower": "Down"
+//	}
+//
+// For key names, see [ParseButton].
+func (b *Bindings) Load(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	keys := map[string]string{}
+	if err := json.Unmarshal(content, &keys); err != nil {
+		return err
+	}
+	for name, key := range keys {
+		button, err := ParseButton(key)
+		if err != nil {
+			return fmt.Errorf("binding for action '%s': %s", name, err.Error())
+		}
+		b.Set(name, button)
+	}
+	return nil
+}
+
+// Actions returns all registered actions, in the order of registration.
+func (b *Bindings) Actions() []*Action {
+	return b.actions
+}
+
+// Conflicts returns all keys that are bound to more than one action name,
+// together with the names of the respective actions.
+// Unbound actions are ignored.
+func (b *Bindings) Conflicts() map[pixel.Button][]string {
+	names := map[pixel.Button][]string{}
+	for _, a := range b.actions {
+		if !a.Bound() {
+			continue
+		}
+		if !contains(names[a.Key], a.Name) {
+			names[a.Key] = append(names[a.Key], a.Name)
+		}
+	}
+	for key, n := range names {
+		if len(n) < 2 {
+			delete(names, key)
+		}
+	}
+	return names
+}
+
+// conflictMessages returns a message for each conflicting key, sorted by key.
+func (b *Bindings) conflictMessages() []string {
+	conflicts := b.Conflicts()
+	keys := make([]pixel.Button, 0, len(conflicts))
+	for key := range conflicts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	messages := make([]string, len(keys))
+	for i, key := range keys {
+		messages[i] = fmt.Sprintf("key %s is bound to multiple actions: %s", key, strings.Join(conflicts[key], ", "))
+	}
+	return messages
+}
+
+// ParseButton parses the name of a key or mouse button.
+// Names are those returned by [pixel.Button.String], like "A", "Up", "F1" or "MouseButtonLeft".
+// Parsing is case-insensitive.
+func ParseButton(name string) (pixel.Button, error) {
+	for b := pixel.MouseButton1; b <= pixel.KeyMenu; b++ {
+		str := b.String()
+		if str != pixel.UnknownButton.String() && strings.EqualFold(str, name) {
+			return b, nil
+		}
+	}
+	return pixel.UnknownButton, fmt.Errorf("unknown key '%s'", name)
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package window_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleBindings() {
+	m := model.New()
+
+	// Keys of drawer actions can be remapped by action name.
+	m.AddUISystem((&window.Window{
+		Keys: map[string]pixel.Button{
+			"recorder.toggle": pixel.KeyF9,
+		},
+	}).With(&window.Recorder{}))
+	// Output:
+}
+
+func TestBindings(t *testing.T) {
+	b := window.NewBindings()
+	b.Set("b.action", pixel.KeyX)
+
+	a1 := b.Register("a.action", pixel.KeyA, "Action A")
+	a2 := b.Register("b.action", pixel.KeyB, "Action B")
+	a3 := b.Register("c.action", pixel.KeyA, "Action C")
+
+	assert.Equal(t, pixel.KeyA, a1.Key)
+	assert.Equal(t, pixel.KeyX, a2.Key)
+	assert.Equal(t, pixel.KeyB, a2.Default)
+	assert.Equal(t, []*window.Action{a1, a2, a3}, b.Actions())
+
+	assert.Equal(t, map[pixel.Button][]string{pixel.KeyA: {"a.action", "c.action"}}, b.Conflicts())
+
+	b.Set("c.action", pixel.KeyC)
+	assert.Equal(t, pixel.KeyC, a3.Key)
+	assert.Empty(t, b.Conflicts())
+
+	a4 := b.Register("a.action", pixel.KeyA, "Action A")
+	assert.Equal(t, pixel.KeyA, a4.Key)
+	assert.Empty(t, b.Conflicts())
+}
+
+func TestBindings_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	err := os.WriteFile(path, []byte(`{"a.action": "left", "b.action": "F12"}`), 0644)
+	assert.Nil(t, err)
+
+	b := window.NewBindings()
+	a := b.Register("a.action", pixel.KeyA, "Action A")
+	assert.Nil(t, b.Load(path))
+
+	assert.Equal(t, pixel.KeyLeft, a.Key)
+	assert.Equal(t, pixel.KeyF12, b.Register("b.action", pixel.KeyB, "Action B").Key)
+
+	err = os.WriteFile(path, []byte(`{"a.action": "foo"}`), 0644)
+	assert.Nil(t, err)
+	assert.NotNil(t, b.Load(path))
+
+	assert.NotNil(t, b.Load(filepath.Join(t.TempDir(), "missing.json")))
+}
+
+func TestParseButton(t *testing.T) {
+	b, err := window.ParseButton("Space")
+	assert.Nil(t, err)
+	assert.Equal(t, pixel.KeySpace, b)
+
+	b, err = window.ParseButton("mousebuttonleft")
+	assert.Nil(t, err)
+	assert.Equal(t, pixel.MouseButtonLeft, b)
+
+	b, err = window.ParseButton("Menu")
+	assert.Nil(t, err)
+	assert.Equal(t, pixel.KeyMenu, b)
+
+	_, err = window.ParseButton("UnknownButton")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,255 @@
+package window
+
+import (
+	"image/color"
+	"math"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+)
+
+// Camera drawer for panning and zooming the content of other drawers.
+//
+// Drawers in a camera draw in world coordinates, which are transformed to pixels by the camera.
+// Without panning and zooming, world coordinates are the pixel coordinates of the camera's drawing area.
+// The world-to-pixel transformation is available to the drawers as [Context.Matrix],
+// and mouse positions are reported in world coordinates.
+// While the mouse is over the camera, the world position is also published in [InputState].
+//
+// The view is panned by dragging with the right mouse button, and zoomed with the mouse wheel.
+// Pressing HOME resets the view, END fits the view to the Extent.
+// Buttons can be remapped via the actions "camera.pan", "camera.reset" and "camera.fit" (see [Bindings]).
+//
+// Mouse and keyboard events are passed to the drawers like in a [Window].
+// Calls to [Finalizer], [Resizer] and [Hitter] are forwarded to the drawers.
+// The camera itself consumes mouse events only while the view is panned or zoomed,
+// so that left clicks still reach drawers below it, and don't conflict with e.g. a [github.com/mlange-42/arche-pixel/plot.Picker].
+type Camera struct {
+	Drawers   []Drawer   // Drawers in increasing z order.
+	Extent    pixel.Rect // World extent to fit the view to. Optional, default the initial drawing area.
+	MinZoom   float64    // Minimum zoom factor. Optional, default 0.01.
+	MaxZoom   float64    // Maximum zoom factor. Optional, default 100.
+	Center    pixel.Vec  // World position at the center of the view. Optional, default the center of the drawing area.
+	Zoom      float64    // Zoom factor, in pixels per world unit. Optional, default 1.
+	bounds    pixel.Rect
+	context   *Context
+	inputs    []*Context
+	canvas    canvas
+	lastMouse pixel.Vec
+	panning   bool
+	panKey    *Action
+	resetKey  *Action
+	fitKey    *Action
+	inputRes  generic.Resource[InputState]
+}
+
+// With adds one or more [Drawer] instances to the camera.
+func (c *Camera) With(drawers ...Drawer) *Camera {
+	c.Drawers = append(c.Drawers, drawers...)
+	return c
+}
+
+// Initialize the drawer.
+func (c *Camera) Initialize(w *ecs.World, ctx *Context) {
+	if c.MinZoom <= 0 {
+		c.MinZoom = 0.01
+	}
+	if c.MaxZoom <= 0 {
+		c.MaxZoom = 100
+	}
+
+	c.bounds = ctx.Bounds
+	local := pixel.R(0, 0, math.Round(ctx.Bounds.W()), math.Round(ctx.Bounds.H()))
+	if c.Extent.Area() == 0 {
+		c.Extent = local
+	}
+
+	c.canvas = newCanvas(ctx.Target, local)
+	c.context = ctx.child(c.canvas, ctx.Bounds)
+	c.inputs = make([]*Context, len(c.Drawers))
+	for i := range c.inputs {
+		c.inputs[i] = &Context{}
+	}
+
+	c.inputRes = generic.NewResource[InputState](w)
+
+	c.panKey = ctx.Bindings.Register("camera.pan", pixel.MouseButtonRight, "Pan view by dragging")
+	c.resetKey = ctx.Bindings.Register("camera.reset", pixel.KeyHome, "Reset view")
+	c.fitKey = ctx.Bindings.Register("camera.fit", pixel.KeyEnd, "Fit view to extent")
+
+	if c.Center == pixel.ZV {
+		c.Center = local.Center()
+	}
+	if c.Zoom <= 0 {
+		c.Zoom = 1
+	}
+	c.panning = false
+	c.updateContext(ctx)
+
+	for _, d := range c.Drawers {
+		d.Initialize(w, c.context)
+	}
+}
+
+// Update the drawer.
+func (c *Camera) Update(w *ecs.World) {
+	for _, d := range c.Drawers {
+		d.Update(w)
+	}
+}
+
+// UpdateInputs handles input events of the previous frame update.
+func (c *Camera) UpdateInputs(w *ecs.World, ctx *Context) {
+	if ctx.Bounds != c.bounds {
+		c.Resize(w, ctx)
+	}
+	if ctx.JustPressed(c.resetKey.Key) {
+		c.Reset()
+	}
+	if ctx.JustPressed(c.fitKey.Key) {
+		c.Fit()
+	}
+
+	mouse := ctx.MousePosition().Sub(ctx.Bounds.Min)
+	if ctx.Pressed(c.panKey.Key) {
+		if c.panning {
+			c.Center = c.Center.Sub(mouse.Sub(c.lastMouse).Scaled(1 / c.Zoom))
+		}
+		c.panning = true
+	} else {
+		c.panning = false
+	}
+	c.lastMouse = mouse
+
+	if scroll := ctx.MouseScroll(); scroll.Y != 0 {
+		c.ZoomAt(mouse, math.Pow(1.2, scroll.Y))
+	}
+
+	child := c.updateContext(ctx)
+	if c.inputRes.Has() && ctx.Bounds.Contains(ctx.MousePosition()) {
+		c.inputRes.Get().MouseWorld = child.MousePosition()
+	}
+
+	updateInputs(w, c.Drawers, child, c.inputs, -1)
+}
+
+// Draw the drawer.
+func (c *Camera) Draw(w *ecs.World, ctx *Context) {
+	if ctx.Bounds != c.bounds {
+		c.Resize(w, ctx)
+	}
+	child := c.updateContext(ctx)
+
+	c.canvas.Clear(color.Transparent)
+	c.canvas.SetMatrix(child.Matrix)
+	for _, d := range c.Drawers {
+		d.Draw(w, child)
+	}
+	c.canvas.Draw(ctx, pixel.IM.Moved(ctx.Bounds.Center()))
+}
+
+// Hit returns true while the view is panned or zoomed,
+// or if the position is inside an interactive region of one of the camera's drawers.
+func (c *Camera) Hit(ctx *Context, pos pixel.Vec) bool {
+	if c.panning || ctx.Pressed(c.panKey.Key) || ctx.MouseScroll().Y != 0 {
+		return true
+	}
+	child := c.updateContext(ctx)
+	world := child.Matrix.Unproject(pos.Sub(ctx.Bounds.Min))
+	for _, d := range c.Drawers {
+		if hit(d, child, world) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resize the drawing area of the camera.
+// Keeps the world position at the center of the view.
+func (c *Camera) Resize(w *ecs.World, ctx *Context) {
+	c.bounds = ctx.Bounds
+	local := pixel.R(0, 0, math.Round(ctx.Bounds.W()), math.Round(ctx.Bounds.H()))
+	if c.canvas.Bounds() == local {
+		return
+	}
+	c.canvas.SetBounds(local)
+	child := c.updateContext(ctx)
+	for _, d := range c.Drawers {
+		resize(w, d, child)
+	}
+}
+
+// Finalize the drawers of the camera.
+func (c *Camera) Finalize(w *ecs.World) {
+	for _, d := range c.Drawers {
+		finalize(w, d)
+	}
+}
+
+// Shortcuts returns the keyboard and mouse shortcuts of the camera.
+func (c *Camera) Shortcuts() []Shortcut {
+	return []Shortcut{
+		c.panKey.Shortcut(),
+		c.resetKey.Shortcut(),
+		c.fitKey.Shortcut(),
+		{Keys: "Mouse wheel", Description: "Zoom view"},
+	}
+}
+
+// Reset the view, so that world coordinates are the pixel coordinates of the drawing area.
+func (c *Camera) Reset() {
+	bounds := c.canvas.Bounds()
+	c.Center = bounds.Center()
+	c.Zoom = 1
+}
+
+// Fit the view to the Extent.
+func (c *Camera) Fit() {
+	bounds := c.canvas.Bounds()
+	c.Center = c.Extent.Center()
+	c.Zoom = c.clampZoom(math.Min(bounds.W()/c.Extent.W(), bounds.H()/c.Extent.H()))
+}
+
+// ZoomAt zooms the view by the given factor, keeping the world position under the given pixel position fixed.
+// The pixel position is relative to the bottom left corner of the camera's drawing area.
+func (c *Camera) ZoomAt(pos pixel.Vec, factor float64) {
+	matrix := c.Matrix()
+	world := matrix.Unproject(pos)
+
+	c.Zoom = c.clampZoom(c.Zoom * factor)
+	c.Center = world.Sub(pos.Sub(c.canvas.Bounds().Center()).Scaled(1 / c.Zoom))
+}
+
+// Matrix returns the transformation from world coordinates to pixels of the camera's drawing area.
+func (c *Camera) Matrix() pixel.Matrix {
+	return pixel.IM.Moved(c.Center.Scaled(-1)).Scaled(pixel.ZV, c.Zoom).Moved(c.canvas.Bounds().Center())
+}
+
+// children returns the drawers of the camera.
+func (c *Camera) children() []Drawer {
+	return c.Drawers
+}
+
+// childContext returns the context of the camera's drawers.
+func (c *Camera) childContext(index int, ctx *Context) *Context {
+	return c.updateContext(ctx)
+}
+
+// active returns whether the drawer with the given index is shown, which is always the case.
+func (c *Camera) active(index int) bool {
+	return true
+}
+
+// updateContext updates the context of the drawers to the current bounds and view.
+func (c *Camera) updateContext(ctx *Context) *Context {
+	matrix := c.Matrix()
+	c.context.Input = matrixInput{Input: offsetInput{Input: ctx.Input, offset: ctx.Bounds.Min}, matrix: matrix}
+	c.context.Bounds = c.canvas.Bounds()
+	c.context.Matrix = matrix
+	return c.context
+}
+
+func (c *Camera) clampZoom(zoom float64) float64 {
+	return math.Max(c.MinZoom, math.Min(c.MaxZoom, zoom))
+}
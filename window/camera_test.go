@@ -0,0 +1,124 @@
+package window_test
+
+import (
+	"testing"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/backends/opengl"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleCamera() {
+	// Create a new model.
+	m := model.New()
+
+	// Limit the the simulation speed.
+	m.TPS = 30
+
+	// Create a camera for panning and zooming the content of drawers.
+	// The view is fitted to the extent with END, and reset with HOME.
+	camera := (&window.Camera{
+		Extent: pixel.R(0, 0, 2000, 1000),
+	}).With(&RectDrawer{})
+
+	// Add the camera to a window.
+	m.AddUISystem((&window.Window{}).With(camera))
+
+	// Add a termination system that ends the simulation.
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+
+	// Run the simulation.
+	// Due to the use of the OpenGL UI system, the model must be run via [window.Run].
+	// Comment out the code line above, and uncomment the next line to run this example stand-alone.
+
+	// window.Run(m)
+
+	// Output:
+}
+
+func TestCamera(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	drawer := LifecycleDrawer{}
+	camera := (&window.Camera{Zoom: 2}).With(&RectDrawer{}, &drawer)
+	m.AddUISystem((&window.Window{Bounds: window.B(0, 0, 400, 300)}).With(camera))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	m.Run()
+
+	assert.Equal(t, pixel.V(200, 150), camera.Center)
+	assert.Equal(t, 2.0, camera.Zoom)
+	assert.Equal(t, 1, drawer.Finalized)
+}
+
+func TestCamera_Inputs(t *testing.T) {
+	win, err := opengl.NewWindow(opengl.WindowConfig{Bounds: pixel.R(0, 0, 200, 100), Invisible: true})
+	assert.Nil(t, err)
+	defer win.Destroy()
+
+	m := model.New()
+	input := clickInput{}
+	ctx := window.NewContext(win, pixel.R(0, 0, 200, 100), &input)
+	ctx.Bindings.Set("camera.pan", pixel.MouseButtonLeft)
+
+	drawer := ClickDrawer{}
+	camera := (&window.Camera{Extent: pixel.R(0, 0, 400, 100)}).With(&drawer)
+	camera.Initialize(&m.World, ctx)
+
+	assertVec(t, pixel.V(10, 20), camera.Matrix().Project(pixel.V(10, 20)))
+
+	camera.ZoomAt(pixel.V(50, 50), 2)
+	assert.Equal(t, 2.0, camera.Zoom)
+	assertVec(t, pixel.V(50, 50), camera.Matrix().Unproject(pixel.V(50, 50)))
+	assertVec(t, pixel.V(75, 50), camera.Center)
+
+	input.mouse = pixel.V(100, 50)
+	camera.UpdateInputs(&m.World, ctx)
+	input.mouse = pixel.V(110, 50)
+	camera.UpdateInputs(&m.World, ctx)
+
+	assertVec(t, pixel.V(70, 50), camera.Center)
+	assertVec(t, pixel.V(75, 50), drawer.Position)
+
+	camera.Fit()
+	assert.Equal(t, 0.5, camera.Zoom)
+	assertVec(t, pixel.V(200, 50), camera.Center)
+
+	camera.Reset()
+	assert.Equal(t, 1.0, camera.Zoom)
+	assertVec(t, pixel.V(100, 50), camera.Center)
+}
+
+func TestCamera_Hit(t *testing.T) {
+	win, err := opengl.NewWindow(opengl.WindowConfig{Bounds: pixel.R(0, 0, 200, 100), Invisible: true})
+	assert.Nil(t, err)
+	defer win.Destroy()
+
+	m := model.New()
+	ctx := window.NewContext(win, pixel.R(0, 0, 200, 100), &clickInput{})
+
+	camera := (&window.Camera{}).With(&ClickDrawer{})
+	camera.Initialize(&m.World, ctx)
+
+	assert.False(t, camera.Hit(ctx, pixel.V(50, 50)))
+	assert.True(t, camera.Hit(ctx, pixel.V(150, 50)))
+
+	ctx.Bindings.Set("camera.pan", pixel.MouseButtonLeft)
+	assert.True(t, camera.Hit(ctx, pixel.V(50, 50)))
+}
+
+func assertVec(t *testing.T, expected, actual pixel.Vec) {
+	assert.InDelta(t, expected.X, actual.X, 0.000001)
+	assert.InDelta(t, expected.Y, actual.Y, 0.000001)
+}
@@ -0,0 +1,246 @@
+package window
+
+import (
+	"image/color"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/backends/opengl"
+	"github.com/gopxl/pixel/v2/ext/text"
+	"github.com/mlange-42/arche/ecs"
+)
+
+// Target is the drawing target of a [Drawer].
+// It is implemented by [opengl.Window] and [opengl.Canvas].
+type Target interface {
+	pixel.BasicTarget
+	// Clear fills the whole target with a single color.
+	Clear(c color.Color)
+}
+
+// Input provides user input to a [Drawer].
+// It is implemented by [opengl.Window].
+type Input interface {
+	// Pressed returns whether a button is currently pressed down.
+	Pressed(button pixel.Button) bool
+	// JustPressed returns whether a button has just been pressed down.
+	JustPressed(button pixel.Button) bool
+	// JustReleased returns whether a button has just been released up.
+	JustReleased(button pixel.Button) bool
+	// Repeated returns whether a repeat event has been triggered on a button.
+	Repeated(button pixel.Button) bool
+	// MousePosition returns the current mouse position.
+	MousePosition() pixel.Vec
+	// MouseScroll returns the mouse scroll amount since the last update.
+	MouseScroll() pixel.Vec
+	// Typed returns the text typed on the keyboard since the last update.
+	Typed() string
+}
+
+// Context is the drawing context passed to a [Drawer].
+//
+// It provides the drawing target, the bounds of the drawing area, and user input.
+// Context implements [pixel.Target], so it can be drawn to directly:
+//
+//	sprite.Draw(ctx, matrix)
+//
+// Drawers should draw within the context's Bounds, and should not make assumptions about the underlying target.
+// This allows for composition in layouts like [Grid], as well as for testing drawers without an OpenGL window.
+//
+// Drawers should register their keyboard shortcuts as [Action] instances in Bindings, rather than using fixed keys.
+type Context struct {
+	Target                  // Drawing target.
+	Input                   // User input. Mouse positions are relative to the target.
+	Bounds   pixel.Rect     // Bounds of the drawing area, in target coordinates.
+	Bindings *Bindings      // Key bindings, shared by all contexts of a window.
+	Matrix   pixel.Matrix   // Transformation from drawing coordinates to pixels. Identity, except in a [Camera].
+	Font     *text.Atlas    // Font for drawing text. Drawers should use it unless they are configured with their own font.
+	Theme    *Theme         // Colors for drawing. Drawers should use them unless they are configured with their own colors.
+	window   *opengl.Window // Underlying window, if any.
+}
+
+// NewContext creates a new drawing context.
+// If input is nil, the context does not receive any user input.
+func NewContext(target Target, bounds pixel.Rect, input Input) *Context {
+	if input == nil {
+		input = noInput{}
+	}
+	return &Context{
+		Target:   target,
+		Input:    input,
+		Bounds:   bounds,
+		Bindings: NewBindings(),
+		Matrix:   pixel.IM,
+		Font:     defaultFont,
+		Theme:    LightTheme(),
+	}
+}
+
+// Window returns the underlying OpenGL window.
+// Returns nil for contexts that were not created by a [Window].
+func (c *Context) Window() *opengl.Window {
+	return c.window
+}
+
+// child creates a context for drawing to a sub-region of this context, using the given target.
+func (c *Context) child(target Target, region pixel.Rect) *Context {
+	return &Context{
+		Target:   target,
+		Input:    offsetInput{Input: c.Input, offset: region.Min},
+		Bounds:   pixel.R(0, 0, region.W(), region.H()),
+		Bindings: c.Bindings,
+		Matrix:   pixel.IM,
+		Font:     c.Font,
+		Theme:    c.Theme,
+		window:   c.window,
+	}
+}
+
+// offsetInput shifts mouse positions by an offset.
+type offsetInput struct {
+	Input
+	offset pixel.Vec
+}
+
+func (i offsetInput) MousePosition() pixel.Vec {
+	return i.Input.MousePosition().Sub(i.offset)
+}
+
+// matrixInput transforms mouse positions from pixels to drawing coordinates.
+type matrixInput struct {
+	Input
+	matrix pixel.Matrix
+}
+
+func (i matrixInput) MousePosition() pixel.Vec {
+	return i.matrix.Unproject(i.Input.MousePosition())
+}
+
+// filteredInput hides mouse or keyboard events.
+// Mouse positions are always passed through.
+type filteredInput struct {
+	Input
+	mouse bool // Whether mouse button and scroll events are passed.
+	keys  bool // Whether keyboard events are passed.
+}
+
+func (i filteredInput) Pressed(button pixel.Button) bool {
+	return i.passes(button) && i.Input.Pressed(button)
+}
+
+func (i filteredInput) JustPressed(button pixel.Button) bool {
+	return i.passes(button) && i.Input.JustPressed(button)
+}
+
+func (i filteredInput) JustReleased(button pixel.Button) bool {
+	return i.passes(button) && i.Input.JustReleased(button)
+}
+
+func (i filteredInput) Repeated(button pixel.Button) bool {
+	return i.passes(button) && i.Input.Repeated(button)
+}
+
+func (i filteredInput) MouseScroll() pixel.Vec {
+	if !i.mouse {
+		return pixel.Vec{}
+	}
+	return i.Input.MouseScroll()
+}
+
+func (i filteredInput) Typed() string {
+	if !i.keys {
+		return ""
+	}
+	return i.Input.Typed()
+}
+
+func (i filteredInput) passes(button pixel.Button) bool {
+	if isMouseButton(button) {
+		return i.mouse
+	}
+	return i.keys
+}
+
+// isMouseButton returns whether the button is a mouse button, rather than a key.
+func isMouseButton(button pixel.Button) bool {
+	return button >= pixel.MouseButton1 && button <= pixel.MouseButton8
+}
+
+// noInput is an [Input] without any user input.
+type noInput struct{}
+
+func (i noInput) Pressed(button pixel.Button) bool      { return false }
+func (i noInput) JustPressed(button pixel.Button) bool  { return false }
+func (i noInput) JustReleased(button pixel.Button) bool { return false }
+func (i noInput) Repeated(button pixel.Button) bool     { return false }
+func (i noInput) MousePosition() pixel.Vec              { return pixel.Vec{} }
+func (i noInput) MouseScroll() pixel.Vec                { return pixel.Vec{} }
+func (i noInput) Typed() string                         { return "" }
+
+// WindowDrawer is the former version of the [Drawer] interface, drawing directly to an OpenGL window.
+// Use [Adapt] to add it to a [Window].
+// Wrapped drawers can implement [Finalizer], [Resizer], [Hitter] and [Describer].
+type WindowDrawer interface {
+	Initialize(w *ecs.World, win *opengl.Window)
+	Update(w *ecs.World)
+	UpdateInputs(w *ecs.World, win *opengl.Window)
+	Draw(w *ecs.World, win *opengl.Window)
+}
+
+// Adapt wraps a [WindowDrawer] to be used as a [Drawer].
+//
+// The wrapped drawer draws directly to the underlying window of the [Context].
+// It is therefore not restricted to the drawing area assigned by layouts like [Grid].
+// Calls to [Finalizer], [Resizer], [Hitter] and [Describer] are forwarded to the wrapped drawer.
+// [VectorExporter] is not forwarded, as the wrapped drawer's output is only captured in the raster screenshot.
+func Adapt(d WindowDrawer) Drawer {
+	return &adapter{drawer: d}
+}
+
+type adapter struct {
+	drawer WindowDrawer
+}
+
+func (a *adapter) Initialize(w *ecs.World, ctx *Context) {
+	if ctx.Window() == nil {
+		panic("drawers wrapped with Adapt require an OpenGL window, and can't be used in headless windows")
+	}
+	a.drawer.Initialize(w, ctx.Window())
+}
+
+func (a *adapter) Update(w *ecs.World) {
+	a.drawer.Update(w)
+}
+
+func (a *adapter) UpdateInputs(w *ecs.World, ctx *Context) {
+	a.drawer.UpdateInputs(w, ctx.Window())
+}
+
+func (a *adapter) Draw(w *ecs.World, ctx *Context) {
+	a.drawer.Draw(w, ctx.Window())
+}
+
+func (a *adapter) Finalize(w *ecs.World) {
+	if f, ok := a.drawer.(Finalizer); ok {
+		f.Finalize(w)
+	}
+}
+
+func (a *adapter) Resize(w *ecs.World, ctx *Context) {
+	if r, ok := a.drawer.(Resizer); ok {
+		r.Resize(w, ctx)
+	}
+}
+
+func (a *adapter) Hit(ctx *Context, pos pixel.Vec) bool {
+	if h, ok := a.drawer.(Hitter); ok {
+		return h.Hit(ctx, pos)
+	}
+	return false
+}
+
+func (a *adapter) Shortcuts() []Shortcut {
+	if d, ok := a.drawer.(Describer); ok {
+		return d.Shortcuts()
+	}
+	return nil
+}
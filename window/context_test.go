@@ -0,0 +1,51 @@
+package window_test
+
+import (
+	"testing"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/backends/opengl"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleAdapt() {
+	m := model.New()
+
+	// Drawers that draw directly to an OpenGL window can be used via an adapter.
+	m.AddUISystem((&window.Window{}).
+		With(window.Adapt(&LegacyDrawer{})))
+	// Output:
+}
+
+func TestContext_NoInput(t *testing.T) {
+	ctx := window.NewContext(nil, pixel.R(0, 0, 800, 600), nil)
+
+	assert.Equal(t, pixel.R(0, 0, 800, 600), ctx.Bounds)
+	assert.Nil(t, ctx.Window())
+
+	assert.False(t, ctx.Pressed(pixel.KeySpace))
+	assert.False(t, ctx.JustPressed(pixel.KeySpace))
+	assert.False(t, ctx.JustReleased(pixel.KeySpace))
+	assert.False(t, ctx.Repeated(pixel.KeySpace))
+	assert.Equal(t, pixel.Vec{}, ctx.MousePosition())
+	assert.Equal(t, pixel.Vec{}, ctx.MouseScroll())
+	assert.Equal(t, "", ctx.Typed())
+}
+
+// LegacyDrawer is an example drawer that draws directly to an OpenGL window.
+type LegacyDrawer struct{}
+
+// Initialize the LegacyDrawer.
+func (d *LegacyDrawer) Initialize(w *ecs.World, win *opengl.Window) {}
+
+// Update the LegacyDrawer.
+func (d *LegacyDrawer) Update(w *ecs.World) {}
+
+// UpdateInputs handles input events of the previous frame update.
+func (d *LegacyDrawer) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+
+// Draw the LegacyDrawer's stuff.
+func (d *LegacyDrawer) Draw(w *ecs.World, win *opengl.Window) {}
@@ -4,7 +4,6 @@ import (
 	"image/color"
 
 	pixel "github.com/gopxl/pixel/v2"
-	"github.com/gopxl/pixel/v2/backends/opengl"
 	"github.com/gopxl/pixel/v2/ext/imdraw"
 	"github.com/mlange-42/arche-model/resource"
 	"github.com/mlange-42/arche-pixel/window"
@@ -23,7 +22,7 @@ type RectDrawer struct {
 }
 
 // Initialize the RectDrawer.
-func (d *RectDrawer) Initialize(w *ecs.World, win *opengl.Window) {
+func (d *RectDrawer) Initialize(w *ecs.World, ctx *window.Context) {
 	// Create a drawer from the Pixel engine.
 	d.dr = *imdraw.New(nil)
 }
@@ -32,10 +31,10 @@ func (d *RectDrawer) Initialize(w *ecs.World, win *opengl.Window) {
 func (d *RectDrawer) Update(w *ecs.World) {}
 
 // UpdateInputs handles input events of the previous frame update.
-func (d *RectDrawer) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (d *RectDrawer) UpdateInputs(w *ecs.World, ctx *window.Context) {}
 
 // Draw the RectDrawer's stuff.
-func (d *RectDrawer) Draw(w *ecs.World, win *opengl.Window) {
+func (d *RectDrawer) Draw(w *ecs.World, ctx *window.Context) {
 	// Get a resource from the world.
 	tick := ecs.GetResource[resource.Tick](w)
 	offset := float64(tick.Tick)
@@ -45,8 +44,8 @@ func (d *RectDrawer) Draw(w *ecs.World, win *opengl.Window) {
 	d.dr.Push(pixel.V(50+offset, 50+offset), pixel.V(250+offset, 200+offset))
 	d.dr.Rectangle(0)
 
-	// Draw everything on the window.
-	d.dr.Draw(win)
+	// Draw everything on the drawing context.
+	d.dr.Draw(ctx)
 
 	// Reset the drawer
 	d.dr.Reset()
@@ -0,0 +1,101 @@
+package window
+
+import (
+	"os"
+	"unicode"
+
+	"github.com/gopxl/pixel/v2/ext/text"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// FontRunes are the characters included in fonts created by [NewFont], [LoadFont], [GoFont] and [GoMonoFont]
+// if no characters are given explicitly.
+// Covers ASCII, Latin, Greek and Cyrillic letters, as well as common punctuation, arrows and mathematical symbols.
+var FontRunes = concatRunes(
+	text.ASCII,
+	text.RangeTable(unicode.Latin),
+	text.RangeTable(unicode.Greek),
+	text.RangeTable(unicode.Cyrillic),
+	text.RangeTable(unicode.Sm),
+	text.RangeTable(unicode.Sc),
+	text.RangeTable(unicode.Po),
+	text.RangeTable(unicode.Pd),
+	text.RangeTable(unicode.Pi),
+	text.RangeTable(unicode.Pf),
+	text.RangeTable(&unicode.RangeTable{R16: []unicode.Range16{{Lo: 0x2190, Hi: 0x21ff, Stride: 1}}}),
+)
+
+// defaultFont is the built-in bitmap font, covering only ASCII.
+var defaultFont = text.NewAtlas(basicfont.Face7x13, text.ASCII)
+
+// NewFont creates a font atlas from a font face, for use with [text.New].
+// If no characters are given, the atlas includes [FontRunes].
+// Characters not supported by the face are omitted.
+func NewFont(face font.Face, runes ...[]rune) *text.Atlas {
+	if len(runes) == 0 {
+		runes = [][]rune{FontRunes}
+	}
+	return text.NewAtlas(face, runes...)
+}
+
+// LoadFont loads a TrueType (TTF) or OpenType (OTF) font file, with the given size in points.
+// If no characters are given, the atlas includes [FontRunes].
+func LoadFont(path string, size float64, runes ...[]rune) (*text.Atlas, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseFont(content, size, runes...)
+}
+
+// GoFont creates an atlas of the built-in proportional Go font, with the given size in points.
+// If no characters are given, the atlas includes [FontRunes].
+func GoFont(size float64, runes ...[]rune) *text.Atlas {
+	atlas, err := parseFont(goregular.TTF, size, runes...)
+	if err != nil {
+		panic(err)
+	}
+	return atlas
+}
+
+// GoMonoFont creates an atlas of the built-in monospaced Go font, with the given size in points.
+// If no characters are given, the atlas includes [FontRunes].
+//
+// Drawers that show tables, like the inspector drawers in package plot, should use a monospaced font.
+func GoMonoFont(size float64, runes ...[]rune) *text.Atlas {
+	atlas, err := parseFont(gomono.TTF, size, runes...)
+	if err != nil {
+		panic(err)
+	}
+	return atlas
+}
+
+// parseFont creates an atlas from the content of a TTF or OTF font file.
+func parseFont(content []byte, size float64, runes ...[]rune) (*text.Atlas, error) {
+	f, err := opentype.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewFont(face, runes...), nil
+}
+
+// concatRunes concatenates slices of runes.
+func concatRunes(runes ...[]rune) []rune {
+	result := []rune{}
+	for _, r := range runes {
+		result = append(result, r...)
+	}
+	return result
+}
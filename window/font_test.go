@@ -0,0 +1,102 @@
+package window_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/text"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func ExampleGoFont() {
+	m := model.New()
+
+	// Create a Window with a larger, Unicode-capable font for all drawers.
+	// Use window.LoadFont to load a TTF or OTF font from a file instead.
+	win := (&window.Window{Font: window.GoFont(16)}).
+		With(&RectDrawer{})
+
+	m.AddUISystem(win)
+	// Output:
+}
+
+func TestNewContext_Font(t *testing.T) {
+	ctx := window.NewContext(nil, pixel.R(0, 0, 100, 100), nil)
+	assert.NotNil(t, ctx.Font)
+	assert.True(t, ctx.Font.Contains('A'))
+}
+
+func TestGoFont(t *testing.T) {
+	font := window.GoFont(20)
+	assert.True(t, font.Contains('A'))
+	assert.True(t, font.Contains('ä'))
+	assert.True(t, font.Contains('α'))
+	assert.True(t, font.Contains('Ж'))
+	assert.True(t, font.Contains('→'))
+	assert.Greater(t, font.LineHeight(), 20.0)
+
+	mono := window.GoMonoFont(12)
+	assert.Equal(t, mono.Glyph('i').Advance, mono.Glyph('W').Advance)
+
+	ascii := window.GoFont(12, text.ASCII)
+	assert.True(t, ascii.Contains('A'))
+	assert.False(t, ascii.Contains('ä'))
+}
+
+func TestLoadFont(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "font.ttf")
+	assert.Nil(t, os.WriteFile(path, goregular.TTF, 0644))
+
+	font, err := window.LoadFont(path, 14)
+	assert.Nil(t, err)
+	assert.True(t, font.Contains('ß'))
+
+	_, err = window.LoadFont(filepath.Join(dir, "missing.ttf"), 14)
+	assert.NotNil(t, err)
+
+	invalid := filepath.Join(dir, "invalid.ttf")
+	assert.Nil(t, os.WriteFile(invalid, []byte("no font"), 0644))
+	_, err = window.LoadFont(invalid, 14)
+	assert.NotNil(t, err)
+}
+
+func TestWindow_Font(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+
+	font := window.GoFont(16)
+	drawer := FontDrawer{}
+	m.AddUISystem((&window.Window{Font: font}).
+		With((&window.Camera{}).With(&drawer)))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	m.Run()
+
+	assert.Equal(t, font, drawer.font)
+}
+
+// FontDrawer records the font of its context.
+type FontDrawer struct {
+	font *text.Atlas
+}
+
+func (d *FontDrawer) Initialize(w *ecs.World, ctx *window.Context) {
+	d.font = ctx.Font
+}
+func (d *FontDrawer) Update(w *ecs.World)                            {}
+func (d *FontDrawer) UpdateInputs(w *ecs.World, ctx *window.Context) {}
+func (d *FontDrawer) Draw(w *ecs.World, ctx *window.Context) {
+	txt := text.New(pixel.V(0, 0), ctx.Font)
+	txt.WriteString("Grüße, αβγ")
+	txt.Draw(ctx, pixel.IM.Moved(pixel.V(10, 10)))
+}
@@ -0,0 +1,125 @@
+package window
+
+import (
+	"bytes"
+	"compress/lzw"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"io"
+)
+
+var errGifFrameSize = errors.New("frame size does not match GIF size")
+
+const gifLiteralWidth = 8 // LZW literal width for 256 colors
+
+// gifWriter writes frames into an animated GIF.
+//
+// Frames are streamed to the underlying writer, so memory use does not grow with the number of frames.
+// All frames share a fixed global palette.
+type gifWriter struct {
+	writer  io.Writer
+	width   int
+	height  int
+	delay   int
+	palette color.Palette
+	frame   *image.Paletted
+	buffer  bytes.Buffer
+	err     error
+}
+
+// newGifWriter creates a new gifWriter and writes the file header.
+// Delay is the time between frames, in 100ths of a second.
+func newGifWriter(w io.Writer, width, height, delay int) (*gifWriter, error) {
+	g := gifWriter{
+		writer:  w,
+		width:   width,
+		height:  height,
+		delay:   delay,
+		palette: palette.Plan9,
+	}
+	g.frame = image.NewPaletted(image.Rect(0, 0, width, height), g.palette)
+
+	g.write([]byte("GIF89a"))
+	g.u16(uint16(width))
+	g.u16(uint16(height))
+	g.write([]byte{
+		0x80 | 0x70 | 0x07, // Global color table of 256 colors, 8 bit color resolution
+		0,                  // Background color index
+		0,                  // Pixel aspect ratio
+	})
+	for _, c := range g.palette {
+		r, gr, b, _ := c.RGBA()
+		g.write([]byte{uint8(r >> 8), uint8(gr >> 8), uint8(b >> 8)})
+	}
+
+	// Loop forever
+	g.write([]byte{0x21, 0xff, 0x0b})
+	g.write([]byte("NETSCAPE2.0"))
+	g.write([]byte{0x03, 0x01, 0x00, 0x00, 0x00})
+
+	return &g, g.err
+}
+
+// WriteFrame converts an image to the palette and appends it to the animation.
+func (g *gifWriter) WriteFrame(img image.Image) error {
+	if b := img.Bounds(); b.Dx() != g.width || b.Dy() != g.height {
+		return errGifFrameSize
+	}
+	draw.FloydSteinberg.Draw(g.frame, g.frame.Rect, img, img.Bounds().Min)
+
+	// Graphic control extension, with the frame delay
+	g.write([]byte{0x21, 0xf9, 0x04, 0x00})
+	g.u16(uint16(g.delay))
+	g.write([]byte{0x00, 0x00})
+
+	// Image descriptor, without local color table
+	g.write([]byte{0x2c})
+	g.u16(0)
+	g.u16(0)
+	g.u16(uint16(g.width))
+	g.u16(uint16(g.height))
+	g.write([]byte{0x00})
+
+	g.buffer.Reset()
+	lw := lzw.NewWriter(&g.buffer, lzw.LSB, gifLiteralWidth)
+	if _, err := lw.Write(g.frame.Pix); err != nil {
+		return err
+	}
+	if err := lw.Close(); err != nil {
+		return err
+	}
+
+	g.write([]byte{gifLiteralWidth})
+	data := g.buffer.Bytes()
+	for len(data) > 0 {
+		n := min(len(data), 255)
+		g.write([]byte{uint8(n)})
+		g.write(data[:n])
+		data = data[n:]
+	}
+	g.write([]byte{0x00})
+
+	return g.err
+}
+
+// Close writes the GIF trailer.
+// Does not close the underlying writer.
+func (g *gifWriter) Close() error {
+	g.write([]byte{0x3b})
+	return g.err
+}
+
+func (g *gifWriter) u16(v uint16) {
+	g.write(binary.LittleEndian.AppendUint16(nil, v))
+}
+
+func (g *gifWriter) write(b []byte) {
+	if g.err != nil {
+		return
+	}
+	_, g.err = g.writer.Write(b)
+}
@@ -0,0 +1,40 @@
+package window
+
+import (
+	"image"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGifWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.gif")
+	file, err := os.Create(path)
+	assert.Nil(t, err)
+
+	g, err := newGifWriter(file, 40, 30, 5)
+	assert.Nil(t, err)
+
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, g.WriteFrame(img))
+	}
+	assert.Equal(t, errGifFrameSize, g.WriteFrame(image.NewRGBA(image.Rect(0, 0, 10, 10))))
+
+	assert.Nil(t, g.Close())
+	assert.Nil(t, file.Close())
+
+	file, err = os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+
+	anim, err := gif.DecodeAll(file)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(anim.Image))
+	assert.Equal(t, []int{5, 5, 5}, anim.Delay)
+	assert.Equal(t, 0, anim.LoopCount)
+	assert.Equal(t, image.Rect(0, 0, 40, 30), anim.Image[2].Rect)
+}
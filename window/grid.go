@@ -0,0 +1,231 @@
+package window
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche/ecs"
+)
+
+// Cell of a [Grid] layout.
+type Cell struct {
+	Drawer  Drawer // Drawer of the cell.
+	Column  int    // Column index, starting at the left.
+	Row     int    // Row index, starting at the top.
+	ColSpan int    // Number of columns covered by the cell. Optional, default 1.
+	RowSpan int    // Number of rows covered by the cell. Optional, default 1.
+}
+
+// Grid drawer for arranging multiple drawers in a single [Window].
+//
+// Columns and rows are sized according to relative weights, separated by a margin.
+// Each [Cell] is drawn into its own canvas, with the coordinate origin at the cell's bottom left corner.
+// Drawing is clipped to the cell, and the cell's [Context] reports mouse positions relative to the cell.
+//
+// Mouse events are only passed to the cell under the mouse cursor.
+// Grids can be nested.
+// Calls to [Finalizer], [Resizer] and [Hitter] are forwarded to the cells' drawers,
+// and the shortcuts of cells implementing [Describer] are shown in the window's help overlay.
+type Grid struct {
+	Columns  []float64 // Relative column widths. Must not be negative, and at least one must be positive. Optional, default a single column.
+	Rows     []float64 // Relative row heights. Must not be negative, and at least one must be positive. Optional, default a single row.
+	Margin   float64   // Margin between and around cells, in pixels. Optional.
+	Cells    []Cell    // Cells of the grid.
+	bounds   pixel.Rect
+	contexts []*Context
+	canvases []canvas
+}
+
+// With adds one or more [Cell] instances to the grid.
+func (g *Grid) With(cells ...Cell) *Grid {
+	g.Cells = append(g.Cells, cells...)
+	return g
+}
+
+// Initialize the drawer.
+func (g *Grid) Initialize(w *ecs.World, ctx *Context) {
+	if len(g.Columns) == 0 {
+		g.Columns = []float64{1}
+	}
+	if len(g.Rows) == 0 {
+		g.Rows = []float64{1}
+	}
+	checkWeights("column", g.Columns)
+	checkWeights("row", g.Rows)
+
+	g.bounds = ctx.Bounds
+	g.contexts = make([]*Context, len(g.Cells))
+	g.canvases = make([]canvas, len(g.Cells))
+
+	for i := range g.Cells {
+		c := &g.Cells[i]
+		if c.ColSpan <= 0 {
+			c.ColSpan = 1
+		}
+		if c.RowSpan <= 0 {
+			c.RowSpan = 1
+		}
+		if c.Column < 0 || c.Row < 0 || c.Column+c.ColSpan > len(g.Columns) || c.Row+c.RowSpan > len(g.Rows) {
+			panic(fmt.Sprintf("grid cell %d out of range", i))
+		}
+
+		bounds := g.CellBounds(i, ctx.Bounds)
+		g.canvases[i] = newCanvas(ctx.Target, pixel.R(0, 0, bounds.W(), bounds.H()))
+		g.contexts[i] = ctx.child(g.canvases[i], bounds)
+
+		c.Drawer.Initialize(w, g.contexts[i])
+	}
+}
+
+// Update the drawer.
+func (g *Grid) Update(w *ecs.World) {
+	for _, c := range g.Cells {
+		c.Drawer.Update(w)
+	}
+}
+
+// UpdateInputs handles input events of the previous frame update.
+func (g *Grid) UpdateInputs(w *ecs.World, ctx *Context) {
+	if ctx.Bounds != g.bounds {
+		g.Resize(w, ctx)
+	}
+	pos := ctx.MousePosition()
+	for i, c := range g.Cells {
+		cellCtx := g.updateContext(i, ctx)
+		if !g.CellBounds(i, ctx.Bounds).Contains(pos) {
+			cellCtx.Input = filteredInput{Input: cellCtx.Input, mouse: false, keys: true}
+		}
+		c.Drawer.UpdateInputs(w, cellCtx)
+	}
+}
+
+// Draw the drawer.
+func (g *Grid) Draw(w *ecs.World, ctx *Context) {
+	if ctx.Bounds != g.bounds {
+		g.Resize(w, ctx)
+	}
+	for i, c := range g.Cells {
+		cellCtx := g.updateContext(i, ctx)
+		canvas := g.canvases[i]
+		canvas.Clear(color.Transparent)
+
+		c.Drawer.Draw(w, cellCtx)
+
+		bounds := g.CellBounds(i, ctx.Bounds)
+		canvas.Draw(ctx, pixel.IM.Moved(bounds.Center()))
+	}
+}
+
+// Hit checks for interactive regions of the cell under the given position.
+func (g *Grid) Hit(ctx *Context, pos pixel.Vec) bool {
+	for i, c := range g.Cells {
+		bounds := g.CellBounds(i, ctx.Bounds)
+		if bounds.Contains(pos) {
+			return hit(c.Drawer, g.updateContext(i, ctx), pos.Sub(bounds.Min))
+		}
+	}
+	return false
+}
+
+// Resize the cells of the grid.
+func (g *Grid) Resize(w *ecs.World, ctx *Context) {
+	g.bounds = ctx.Bounds
+	for i, c := range g.Cells {
+		cellCtx := g.updateContext(i, ctx)
+		if g.canvases[i].Bounds() == cellCtx.Bounds {
+			continue
+		}
+		g.canvases[i].SetBounds(cellCtx.Bounds)
+		resize(w, c.Drawer, cellCtx)
+	}
+}
+
+// Finalize the cells of the grid.
+func (g *Grid) Finalize(w *ecs.World) {
+	for _, c := range g.Cells {
+		finalize(w, c.Drawer)
+	}
+}
+
+// children returns the drawers of all cells.
+func (g *Grid) children() []Drawer {
+	drawers := make([]Drawer, len(g.Cells))
+	for i, c := range g.Cells {
+		drawers[i] = c.Drawer
+	}
+	return drawers
+}
+
+// childContext returns the context of the cell with the given index.
+func (g *Grid) childContext(index int, ctx *Context) *Context {
+	return g.updateContext(index, ctx)
+}
+
+// active returns whether the cell with the given index is shown, which is always the case.
+func (g *Grid) active(index int) bool {
+	return true
+}
+
+// CellBounds calculates the bounds of the cell with the given index,
+// for the given bounds of the entire grid.
+// Cell bounds are rounded to full pixels.
+func (g *Grid) CellBounds(index int, bounds pixel.Rect) pixel.Rect {
+	c := &g.Cells[index]
+	x, w := gridTracks(bounds.W(), g.Margin, g.Columns)
+	y, h := gridTracks(bounds.H(), g.Margin, g.Rows)
+
+	lastCol := c.Column + c.ColSpan - 1
+	lastRow := c.Row + c.RowSpan - 1
+
+	return pixel.R(
+		math.Round(bounds.Min.X+x[c.Column]),
+		math.Round(bounds.Max.Y-y[lastRow]-h[lastRow]),
+		math.Round(bounds.Min.X+x[lastCol]+w[lastCol]),
+		math.Round(bounds.Max.Y-y[c.Row]),
+	)
+}
+
+// updateContext updates the context of a cell to the current bounds of the grid.
+func (g *Grid) updateContext(index int, ctx *Context) *Context {
+	bounds := g.CellBounds(index, ctx.Bounds)
+	cellCtx := g.contexts[index]
+	cellCtx.Input = offsetInput{Input: ctx.Input, offset: bounds.Min}
+	cellCtx.Bounds = pixel.R(0, 0, bounds.W(), bounds.H())
+	return cellCtx
+}
+
+// checkWeights panics if any of the weights is negative, or if none is positive.
+func checkWeights(kind string, weights []float64) {
+	sum := 0.0
+	for _, w := range weights {
+		if w < 0 {
+			panic(fmt.Sprintf("grid %s weights must not be negative, got %v", kind, weights))
+		}
+		sum += w
+	}
+	if sum <= 0 {
+		panic(fmt.Sprintf("grid %s weights must contain at least one positive value, got %v", kind, weights))
+	}
+}
+
+// gridTracks calculates start positions and sizes of grid columns or rows.
+// If the margins exceed the total size, columns or rows get a size of zero.
+func gridTracks(total, margin float64, weights []float64) ([]float64, []float64) {
+	sum := 0.0
+	for _, w := range weights {
+		sum += w
+	}
+	available := max(total-margin*float64(len(weights)+1), 0)
+
+	starts := make([]float64, len(weights))
+	sizes := make([]float64, len(weights))
+	pos := margin
+	for i, w := range weights {
+		starts[i] = pos
+		sizes[i] = available * w / sum
+		pos += sizes[i] + margin
+	}
+	return starts, sizes
+}
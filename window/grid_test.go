@@ -0,0 +1,202 @@
+package window_test
+
+import (
+	"testing"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/backends/opengl"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleGrid() {
+	// Create a new model.
+	m := model.New()
+
+	// Limit the the simulation speed.
+	m.TPS = 30
+
+	// Create a grid layout with two columns and two rows.
+	// The first cell spans both rows of the wider left column.
+	grid := (&window.Grid{
+		Columns: []float64{2, 1},
+		Rows:    []float64{1, 1},
+		Margin:  5,
+	}).With(
+		window.Cell{Drawer: &RectDrawer{}, RowSpan: 2},
+		window.Cell{Drawer: &RectDrawer{}, Column: 1},
+		window.Cell{Drawer: &RectDrawer{}, Column: 1, Row: 1},
+	)
+
+	// Add the grid to a window.
+	m.AddUISystem((&window.Window{}).With(grid))
+
+	// Add a termination system that ends the simulation.
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+
+	// Run the simulation.
+	// Due to the use of the OpenGL UI system, the model must be run via [window.Run].
+	// Comment out the code line above, and uncomment the next line to run this example stand-alone.
+
+	// window.Run(m)
+
+	// Output:
+}
+
+func TestGrid_CellBounds(t *testing.T) {
+	grid := (&window.Grid{
+		Columns: []float64{2, 1},
+		Rows:    []float64{1, 1},
+		Margin:  10,
+	}).With(
+		window.Cell{RowSpan: 2, ColSpan: 1},
+		window.Cell{Column: 1, ColSpan: 1, RowSpan: 1},
+		window.Cell{Column: 1, Row: 1, ColSpan: 1, RowSpan: 1},
+	)
+
+	bounds := pixel.R(0, 0, 330, 210)
+
+	assert.Equal(t, pixel.R(10, 10, 210, 200), grid.CellBounds(0, bounds))
+	assert.Equal(t, pixel.R(220, 110, 320, 200), grid.CellBounds(1, bounds))
+	assert.Equal(t, pixel.R(220, 10, 320, 100), grid.CellBounds(2, bounds))
+
+	bounds = pixel.R(100, 100, 430, 310)
+	assert.Equal(t, pixel.R(320, 110, 420, 200), grid.CellBounds(2, bounds))
+}
+
+func TestGrid_Nested(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	inner := (&window.Grid{Rows: []float64{1, 1}}).With(
+		window.Cell{Drawer: &RectDrawer{}},
+		window.Cell{Drawer: &RectDrawer{}, Row: 1},
+	)
+	outer := (&window.Grid{Columns: []float64{1, 1}, Margin: 5}).With(
+		window.Cell{Drawer: &RectDrawer{}},
+		window.Cell{Drawer: inner, Column: 1},
+	)
+	m.AddUISystem((&window.Window{}).With(outer))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	m.Run()
+}
+
+func TestGrid_PanicRange(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	grid := (&window.Grid{Columns: []float64{1, 1}}).With(
+		window.Cell{Drawer: &RectDrawer{}, Column: 1, ColSpan: 2},
+	)
+	m.AddUISystem((&window.Window{}).With(grid))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	assert.Panics(t, m.Run)
+}
+
+func TestGrid_PanicWeights(t *testing.T) {
+	for _, columns := range [][]float64{{0, 0}, {1, -1}} {
+		m := model.New()
+		m.TPS = 300
+		m.FPS = 0
+
+		grid := (&window.Grid{Columns: columns}).With(
+			window.Cell{Drawer: &RectDrawer{}},
+		)
+		m.AddUISystem((&window.Window{}).With(grid))
+
+		m.AddSystem(&system.FixedTermination{
+			Steps: 10,
+		})
+		assert.Panics(t, m.Run)
+	}
+}
+
+func TestGrid_CellBoundsMargin(t *testing.T) {
+	grid := (&window.Grid{Columns: []float64{1, 1}, Rows: []float64{1}, Margin: 100}).With(
+		window.Cell{ColSpan: 1, RowSpan: 1},
+		window.Cell{Column: 1, ColSpan: 1, RowSpan: 1},
+	)
+	for i := range grid.Cells {
+		bounds := grid.CellBounds(i, pixel.R(0, 0, 150, 150))
+		assert.Equal(t, 0.0, bounds.W())
+		assert.Equal(t, 0.0, bounds.H())
+	}
+}
+
+func TestGrid_Inputs(t *testing.T) {
+	win, err := opengl.NewWindow(opengl.WindowConfig{Bounds: pixel.R(0, 0, 200, 100), Invisible: true})
+	assert.Nil(t, err)
+	defer win.Destroy()
+
+	m := model.New()
+	input := clickInput{}
+	ctx := window.NewContext(win, pixel.R(0, 0, 200, 100), &input)
+
+	left, right := ClickDrawer{}, ClickDrawer{}
+	grid := (&window.Grid{Columns: []float64{1, 1}}).With(
+		window.Cell{Drawer: &left},
+		window.Cell{Drawer: &right, Column: 1},
+	)
+	grid.Initialize(&m.World, ctx)
+
+	input.mouse = pixel.V(175, 50)
+	grid.UpdateInputs(&m.World, ctx)
+
+	assert.Equal(t, 0, left.Clicks)
+	assert.Equal(t, 1, right.Clicks)
+	assert.Equal(t, pixel.V(75, 50), right.Position)
+
+	assert.True(t, grid.Hit(ctx, pixel.V(175, 50)))
+	assert.False(t, grid.Hit(ctx, pixel.V(50, 50)))
+}
+
+// ClickDrawer counts mouse clicks, and consumes clicks in its right half.
+type ClickDrawer struct {
+	Clicks   int
+	Position pixel.Vec
+}
+
+func (d *ClickDrawer) Initialize(w *ecs.World, ctx *window.Context) {}
+
+func (d *ClickDrawer) Update(w *ecs.World) {}
+
+func (d *ClickDrawer) UpdateInputs(w *ecs.World, ctx *window.Context) {
+	if ctx.JustPressed(pixel.MouseButtonLeft) {
+		d.Clicks++
+		d.Position = ctx.MousePosition()
+	}
+}
+
+func (d *ClickDrawer) Draw(w *ecs.World, ctx *window.Context) {}
+
+func (d *ClickDrawer) Hit(ctx *window.Context, pos pixel.Vec) bool {
+	return pos.X > ctx.Bounds.W()/2
+}
+
+// clickInput is an input source for testing, with the left mouse button just pressed.
+type clickInput struct {
+	mouse pixel.Vec
+}
+
+func (i *clickInput) Pressed(button pixel.Button) bool      { return button == pixel.MouseButtonLeft }
+func (i *clickInput) JustPressed(button pixel.Button) bool  { return button == pixel.MouseButtonLeft }
+func (i *clickInput) JustReleased(button pixel.Button) bool { return false }
+func (i *clickInput) Repeated(button pixel.Button) bool     { return false }
+func (i *clickInput) MousePosition() pixel.Vec              { return i.mouse }
+func (i *clickInput) MouseScroll() pixel.Vec                { return pixel.Vec{} }
+func (i *clickInput) Typed() string                         { return "" }
@@ -0,0 +1,111 @@
+package window
+
+import (
+	"fmt"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/gopxl/pixel/v2/ext/text"
+)
+
+// parent is implemented by drawers that contain other drawers, like [Grid].
+type parent interface {
+	// children returns the contained drawers.
+	children() []Drawer
+	// childContext returns the drawing context of the child with the given index.
+	childContext(index int, ctx *Context) *Context
+	// active returns whether the child with the given index is currently shown.
+	active(index int) bool
+}
+
+// helpGroup is a group of shortcuts in the help overlay.
+type helpGroup struct {
+	Title     string
+	Shortcuts []Shortcut
+}
+
+// helpOverlay lists the shortcuts of all drawers of a [Window].
+type helpOverlay struct {
+	text   *text.Text
+	drawer imdraw.IMDraw
+}
+
+func newHelpOverlay(font *text.Atlas) helpOverlay {
+	return helpOverlay{
+		text:   text.New(pixel.V(0, 0), font),
+		drawer: *imdraw.New(nil),
+	}
+}
+
+// Draw the overlay over the entire context.
+func (h *helpOverlay) Draw(ctx *Context, groups []helpGroup) {
+	dr := &h.drawer
+	dr.Color = ctx.Theme.Overlay(220)
+	dr.Push(ctx.Bounds.Min, ctx.Bounds.Max)
+	dr.Rectangle(0)
+	dr.Reset()
+	dr.Draw(ctx)
+	dr.Clear()
+
+	h.text.Clear()
+	h.text.Color = ctx.Theme.Foreground
+	fmt.Fprint(h.text, "Keyboard and mouse shortcuts\n\n")
+	for _, g := range groups {
+		fmt.Fprintf(h.text, "%s\n", g.Title)
+		for _, s := range g.Shortcuts {
+			fmt.Fprintf(h.text, "  %-16s %s\n", s.Keys, s.Description)
+		}
+		fmt.Fprint(h.text, "\n")
+	}
+	h.text.Draw(ctx, pixel.IM.Moved(pixel.V(ctx.Bounds.Min.X+20, ctx.Bounds.Max.Y-30)))
+}
+
+// collectShortcuts collects the shortcuts of drawers implementing [Describer],
+// including drawers nested in containers like [Grid].
+// Shortcuts of drawers of the same type are merged, omitting duplicates.
+func collectShortcuts(drawers []Drawer, groups []helpGroup) []helpGroup {
+	for _, d := range drawers {
+		if p, ok := d.(parent); ok {
+			groups = collectShortcuts(p.children(), groups)
+		}
+		desc, ok := d.(Describer)
+		if !ok {
+			continue
+		}
+		shortcuts := desc.Shortcuts()
+		if len(shortcuts) == 0 {
+			continue
+		}
+		title := typeName(d)
+		idx := findGroup(groups, title)
+		if idx < 0 {
+			groups = append(groups, helpGroup{Title: title})
+			idx = len(groups) - 1
+		}
+		group := &groups[idx]
+		for _, s := range shortcuts {
+			if !hasShortcut(group.Shortcuts, s) {
+				group.Shortcuts = append(group.Shortcuts, s)
+			}
+		}
+	}
+	return groups
+}
+
+func findGroup(groups []helpGroup, title string) int {
+	for i, g := range groups {
+		if g.Title == title {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasShortcut(shortcuts []Shortcut, s Shortcut) bool {
+	for _, sc := range shortcuts {
+		if sc == s {
+			return true
+		}
+	}
+	return false
+}
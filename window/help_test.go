@@ -0,0 +1,54 @@
+package window
+
+import (
+	"testing"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/backends/opengl"
+
+	"github.com/mlange-42/arche/ecs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectShortcuts(t *testing.T) {
+	grid := (&Grid{}).With(
+		Cell{Drawer: &describer{}},
+		Cell{Drawer: (&Grid{}).With(Cell{Drawer: &describer{}}, Cell{Drawer: &Recorder{toggle: &Action{Key: pixel.KeyR, Description: "Record"}}})},
+	)
+	groups := collectShortcuts([]Drawer{grid}, nil)
+
+	assert.Equal(t, []helpGroup{
+		{Title: "window.describer", Shortcuts: []Shortcut{{Keys: "X", Description: "Do something"}}},
+		{Title: "window.Recorder", Shortcuts: []Shortcut{{Keys: "R", Description: "Record"}}},
+	}, groups)
+}
+
+func TestCollectShortcuts_Adapter(t *testing.T) {
+	groups := collectShortcuts([]Drawer{Adapt(&windowDescriber{})}, nil)
+
+	assert.Equal(t, []helpGroup{
+		{Title: "window.windowDescriber", Shortcuts: []Shortcut{{Keys: "Y", Description: "Do something else"}}},
+	}, groups)
+}
+
+type describer struct{}
+
+func (d *describer) Initialize(w *ecs.World, ctx *Context)   {}
+func (d *describer) Update(w *ecs.World)                     {}
+func (d *describer) UpdateInputs(w *ecs.World, ctx *Context) {}
+func (d *describer) Draw(w *ecs.World, ctx *Context)         {}
+
+func (d *describer) Shortcuts() []Shortcut {
+	return []Shortcut{{Keys: "X", Description: "Do something"}}
+}
+
+type windowDescriber struct{}
+
+func (d *windowDescriber) Initialize(w *ecs.World, win *opengl.Window)   {}
+func (d *windowDescriber) Update(w *ecs.World)                           {}
+func (d *windowDescriber) UpdateInputs(w *ecs.World, win *opengl.Window) {}
+func (d *windowDescriber) Draw(w *ecs.World, win *opengl.Window)         {}
+
+func (d *windowDescriber) Shortcuts() []Shortcut {
+	return []Shortcut{{Keys: "Y", Description: "Do something else"}}
+}
@@ -0,0 +1,69 @@
+package window
+
+import (
+	pixel "github.com/gopxl/pixel/v2"
+)
+
+const numButtons = int(pixel.KeyMenu) + 1
+
+// InputState is an ECS resource providing the user input of a [Window] to ordinary systems.
+//
+// The resource is added to the world by the first [Window] that is initialized,
+// and is updated on every UI frame by the window that has the focus or contains the mouse cursor.
+//
+// Just-pressed and just-released buttons, scrolling and typed text are accumulated over UI frames
+// until the next model tick, so that systems see them even if the model runs at a lower TPS than the UI.
+// If the model runs at a higher TPS than the UI, they are seen by all ticks until the next UI frame.
+type InputState struct {
+	Mouse        pixel.Vec // Mouse position, in window pixels.
+	MouseWorld   pixel.Vec // Mouse position, in world coordinates of the [Camera] under the mouse. Equals Mouse outside of cameras.
+	MouseInside  bool      // Whether the mouse is inside the window.
+	Scroll       pixel.Vec // Mouse scroll since the previous model tick.
+	Typed        string    // Text typed since the previous model tick.
+	pressed      [numButtons]bool
+	justPressed  [numButtons]bool
+	justReleased [numButtons]bool
+	tick         int64
+}
+
+// Pressed returns whether a key or mouse button is currently pressed down.
+func (s *InputState) Pressed(button pixel.Button) bool {
+	return validButton(button) && s.pressed[button]
+}
+
+// JustPressed returns whether a key or mouse button was pressed down since the previous model tick.
+func (s *InputState) JustPressed(button pixel.Button) bool {
+	return validButton(button) && s.justPressed[button]
+}
+
+// JustReleased returns whether a key or mouse button was released since the previous model tick.
+func (s *InputState) JustReleased(button pixel.Button) bool {
+	return validButton(button) && s.justReleased[button]
+}
+
+// update the state from the given input.
+// Accumulated events are reset if the tick differs from the tick of the previous update.
+func (s *InputState) update(input Input, mouseInside bool, tick int64) {
+	if tick != s.tick {
+		s.justPressed = [numButtons]bool{}
+		s.justReleased = [numButtons]bool{}
+		s.Scroll = pixel.Vec{}
+		s.Typed = ""
+		s.tick = tick
+	}
+
+	for b := pixel.MouseButton1; b <= pixel.KeyMenu; b++ {
+		s.pressed[b] = input.Pressed(b)
+		s.justPressed[b] = s.justPressed[b] || input.JustPressed(b)
+		s.justReleased[b] = s.justReleased[b] || input.JustReleased(b)
+	}
+	s.Mouse = input.MousePosition()
+	s.MouseWorld = s.Mouse
+	s.MouseInside = mouseInside
+	s.Scroll = s.Scroll.Add(input.MouseScroll())
+	s.Typed += input.Typed()
+}
+
+func validButton(button pixel.Button) bool {
+	return button >= pixel.MouseButton1 && button <= pixel.KeyMenu
+}
@@ -0,0 +1,53 @@
+package window
+
+import (
+	"testing"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInputState(t *testing.T) {
+	state := InputState{}
+	input := buttonInput{buttons: map[pixel.Button]bool{pixel.KeyA: true}, mouse: pixel.V(10, 20)}
+
+	state.update(&input, true, 1)
+	assert.True(t, state.Pressed(pixel.KeyA))
+	assert.True(t, state.JustPressed(pixel.KeyA))
+	assert.False(t, state.JustPressed(pixel.KeyB))
+	assert.Equal(t, pixel.V(10, 20), state.Mouse)
+	assert.Equal(t, pixel.V(10, 20), state.MouseWorld)
+	assert.True(t, state.MouseInside)
+	assert.Equal(t, "a", state.Typed)
+
+	input.buttons = map[pixel.Button]bool{pixel.KeyB: true}
+	state.update(&input, true, 1)
+	assert.False(t, state.Pressed(pixel.KeyA))
+	assert.True(t, state.JustPressed(pixel.KeyA))
+	assert.True(t, state.JustPressed(pixel.KeyB))
+	assert.Equal(t, pixel.V(0, 2), state.Scroll)
+	assert.Equal(t, "aa", state.Typed)
+
+	state.update(&input, false, 2)
+	assert.False(t, state.JustPressed(pixel.KeyA))
+	assert.True(t, state.JustPressed(pixel.KeyB))
+	assert.Equal(t, pixel.V(0, 1), state.Scroll)
+	assert.False(t, state.MouseInside)
+
+	assert.False(t, state.Pressed(pixel.UnknownButton))
+	assert.False(t, state.JustReleased(pixel.UnknownButton))
+}
+
+// buttonInput is an input source for testing, with a set of buttons that are just pressed.
+type buttonInput struct {
+	buttons map[pixel.Button]bool
+	mouse   pixel.Vec
+}
+
+func (i *buttonInput) Pressed(button pixel.Button) bool      { return i.buttons[button] }
+func (i *buttonInput) JustPressed(button pixel.Button) bool  { return i.buttons[button] }
+func (i *buttonInput) JustReleased(button pixel.Button) bool { return false }
+func (i *buttonInput) Repeated(button pixel.Button) bool     { return false }
+func (i *buttonInput) MousePosition() pixel.Vec              { return i.mouse }
+func (i *buttonInput) MouseScroll() pixel.Vec                { return pixel.V(0, 1) }
+func (i *buttonInput) Typed() string                         { return "a" }
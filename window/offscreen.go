@@ -0,0 +1,226 @@
+package window
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	pixel "github.com/gopxl/pixel/v2"
+)
+
+// Offscreen is a drawing [Target] that renders in software, into an in-memory image.
+//
+// It requires neither a display nor an OpenGL context.
+// It is used by headless windows (see [Window]), and can be used for testing drawers:
+//
+//	target := window.NewOffscreen(pixel.R(0, 0, 400, 300))
+//	ctx := window.NewContext(target, target.Bounds(), nil)
+//	drawer.Initialize(world, ctx)
+//	drawer.Draw(world, ctx)
+//	img := target.Image()
+//
+// Triangles are drawn with their vertex colors and pictures, like by an OpenGL canvas.
+// Pictures are sampled without smoothing.
+type Offscreen struct {
+	pixels *pixel.PictureData
+	matrix pixel.Matrix
+	mask   pixel.RGBA
+}
+
+// NewOffscreen creates a new offscreen target with the given bounds.
+func NewOffscreen(bounds pixel.Rect) *Offscreen {
+	o := &Offscreen{
+		matrix: pixel.IM,
+		mask:   pixel.Alpha(1),
+	}
+	o.SetBounds(bounds)
+	return o
+}
+
+// MakeTriangles creates triangles that are drawn to this target.
+func (o *Offscreen) MakeTriangles(t pixel.Triangles) pixel.TargetTriangles {
+	tri := &offscreenTriangles{TrianglesData: pixel.MakeTrianglesData(t.Len()), dst: o}
+	tri.Update(t)
+	return tri
+}
+
+// MakePicture creates a picture that can be drawn to this target.
+func (o *Offscreen) MakePicture(p pixel.Picture) pixel.TargetPicture {
+	return &offscreenPicture{PictureData: pixel.PictureDataFromPicture(p), dst: o}
+}
+
+// SetMatrix sets the matrix that all vertices are projected by.
+func (o *Offscreen) SetMatrix(m pixel.Matrix) {
+	o.matrix = m
+}
+
+// SetColorMask sets a color that all drawn colors are multiplied with.
+// Nil resets the mask to white.
+func (o *Offscreen) SetColorMask(c color.Color) {
+	if c == nil {
+		o.mask = pixel.Alpha(1)
+		return
+	}
+	o.mask = pixel.ToRGBA(c)
+}
+
+// Clear fills the whole target with a single color.
+func (o *Offscreen) Clear(c color.Color) {
+	col := color.RGBAModel.Convert(c).(color.RGBA)
+	for i := range o.pixels.Pix {
+		o.pixels.Pix[i] = col
+	}
+}
+
+// Bounds returns the bounds of the target.
+func (o *Offscreen) Bounds() pixel.Rect {
+	return o.pixels.Rect
+}
+
+// SetBounds resizes the target. The content is cleared.
+func (o *Offscreen) SetBounds(bounds pixel.Rect) {
+	o.pixels = pixel.MakePictureData(bounds)
+}
+
+// Color returns the color of the pixel at the given position.
+// Implements [pixel.PictureColor], so that the target can be drawn to other targets.
+func (o *Offscreen) Color(at pixel.Vec) pixel.RGBA {
+	return o.pixels.Color(at)
+}
+
+// Draw the content of the target onto another target, centered at the origin and transformed by the matrix.
+func (o *Offscreen) Draw(t pixel.Target, matrix pixel.Matrix) {
+	pixel.NewSprite(o.pixels, o.pixels.Rect).Draw(t, matrix)
+}
+
+// Image returns a copy of the content of the target, as an image.
+func (o *Offscreen) Image() *image.RGBA {
+	return o.pixels.Image()
+}
+
+// draw rasterizes triangles, optionally textured with a picture.
+func (o *Offscreen) draw(tri *pixel.TrianglesData, pic *pixel.PictureData) {
+	data := *tri
+	for i := 0; i+2 < len(data); i += 3 {
+		o.drawTriangle(tri, i, pic)
+	}
+}
+
+// drawTriangle rasterizes the triangle starting at the given vertex index.
+// Pixels are drawn if their center is inside the triangle.
+// Pixel centers exactly on an edge are assigned to only one of the triangles that share the edge.
+func (o *Offscreen) drawTriangle(tri *pixel.TrianglesData, first int, pic *pixel.PictureData) {
+	data := *tri
+	idx := [3]int{first, first + 1, first + 2}
+	pos := [3]pixel.Vec{}
+	for k, i := range idx {
+		pos[k] = o.matrix.Project(data[i].Position)
+	}
+	area := edge(pos[0], pos[1], pos[2])
+	if area == 0 {
+		return
+	}
+	if area < 0 {
+		idx[1], idx[2] = idx[2], idx[1]
+		pos[1], pos[2] = pos[2], pos[1]
+		area = -area
+	}
+
+	bounds := o.pixels.Rect
+	minX := math.Max(math.Floor(math.Min(pos[0].X, math.Min(pos[1].X, pos[2].X))), bounds.Min.X)
+	maxX := math.Min(math.Ceil(math.Max(pos[0].X, math.Max(pos[1].X, pos[2].X))), bounds.Max.X)
+	minY := math.Max(math.Floor(math.Min(pos[0].Y, math.Min(pos[1].Y, pos[2].Y))), bounds.Min.Y)
+	maxY := math.Min(math.Ceil(math.Max(pos[0].Y, math.Max(pos[1].Y, pos[2].Y))), bounds.Max.Y)
+
+	for y := minY; y < maxY; y++ {
+		for x := minX; x < maxX; x++ {
+			p := pixel.V(x+0.5, y+0.5)
+			w0, w1, w2 := edge(pos[1], pos[2], p), edge(pos[2], pos[0], p), edge(pos[0], pos[1], p)
+			if !covers(w0, pos[1], pos[2]) || !covers(w1, pos[2], pos[0]) || !covers(w2, pos[0], pos[1]) {
+				continue
+			}
+			w0, w1, w2 = w0/area, w1/area, w2/area
+			if clip, ok := data[idx[0]].ClipRect, data[idx[0]].IsClipped; ok && !clip.Contains(p) {
+				continue
+			}
+			o.blend(p, o.fragment(tri, idx, w0, w1, w2, pic))
+		}
+	}
+}
+
+// fragment calculates the color of a pixel from the barycentric weights of the triangle's vertices.
+func (o *Offscreen) fragment(tri *pixel.TrianglesData, idx [3]int, w0, w1, w2 float64, pic *pixel.PictureData) pixel.RGBA {
+	data := *tri
+	v0, v1, v2 := &data[idx[0]], &data[idx[1]], &data[idx[2]]
+
+	col := v0.Color.Scaled(w0).Add(v1.Color.Scaled(w1)).Add(v2.Color.Scaled(w2)).Mul(o.mask)
+	if pic == nil {
+		return col
+	}
+	intensity := v0.Intensity*w0 + v1.Intensity*w1 + v2.Intensity*w2
+	if intensity == 0 {
+		return col
+	}
+	at := v0.Picture.Scaled(w0).Add(v1.Picture.Scaled(w1)).Add(v2.Picture.Scaled(w2))
+	tex := pic.Color(at)
+	return col.Mul(tex.Scaled(intensity).Add(pixel.Alpha(1 - intensity)))
+}
+
+// blend composes a premultiplied color over the pixel at the given position.
+func (o *Offscreen) blend(at pixel.Vec, src pixel.RGBA) {
+	i := o.pixels.Index(at)
+	dst := o.pixels.Pix[i]
+	inv := 1 - src.A
+	o.pixels.Pix[i] = color.RGBA{
+		R: channel(src.R + float64(dst.R)/255*inv),
+		G: channel(src.G + float64(dst.G)/255*inv),
+		B: channel(src.B + float64(dst.B)/255*inv),
+		A: channel(src.A + float64(dst.A)/255*inv),
+	}
+}
+
+// edge returns twice the signed area of the triangle a, b, p.
+// It is positive if p is left of the line from a to b.
+func edge(a, b, p pixel.Vec) float64 {
+	return (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+}
+
+// covers returns whether a point with the given edge value is inside the edge from a to b.
+// Points exactly on the edge are inside only for one of the two directions of the edge.
+func covers(e float64, a, b pixel.Vec) bool {
+	if e != 0 {
+		return e > 0
+	}
+	return b.Y > a.Y || (b.Y == a.Y && b.X < a.X)
+}
+
+// channel converts a color channel from [0, 1] to [0, 255].
+func channel(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+}
+
+// offscreenTriangles are triangles drawn to an [Offscreen] target.
+type offscreenTriangles struct {
+	*pixel.TrianglesData
+	dst *Offscreen
+}
+
+// Draw the triangles without a picture.
+func (t *offscreenTriangles) Draw() {
+	t.dst.draw(t.TrianglesData, nil)
+}
+
+// offscreenPicture is a picture drawn to an [Offscreen] target.
+type offscreenPicture struct {
+	*pixel.PictureData
+	dst *Offscreen
+}
+
+// Draw the triangles, textured with the picture.
+func (p *offscreenPicture) Draw(t pixel.TargetTriangles) {
+	tri, ok := t.(*offscreenTriangles)
+	if !ok {
+		panic("offscreen picture can only be drawn with triangles of the same target")
+	}
+	p.dst.draw(tri.TrianglesData, p.PictureData)
+}
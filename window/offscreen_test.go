@@ -0,0 +1,104 @@
+package window_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffscreen(t *testing.T) {
+	target := window.NewOffscreen(pixel.R(0, 0, 100, 50))
+	target.Clear(color.Black)
+
+	dr := imdraw.New(nil)
+	dr.Color = color.White
+	dr.Push(pixel.V(10, 10), pixel.V(30, 20))
+	dr.Rectangle(0)
+	dr.Draw(target)
+
+	img := target.Image()
+	assert.Equal(t, 100, img.Rect.Dx())
+	assert.Equal(t, 50, img.Rect.Dy())
+
+	white := 0
+	for i := 0; i < len(img.Pix); i += 4 {
+		if img.Pix[i] == 255 {
+			white++
+		}
+	}
+	assert.Equal(t, 200, white)
+
+	// Image rows are top to bottom.
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, img.RGBAAt(10, 39))
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, img.RGBAAt(29, 30))
+	assert.Equal(t, color.RGBA{0, 0, 0, 255}, img.RGBAAt(9, 39))
+	assert.Equal(t, color.RGBA{0, 0, 0, 255}, img.RGBAAt(10, 40))
+}
+
+func TestOffscreen_Blend(t *testing.T) {
+	target := window.NewOffscreen(pixel.R(0, 0, 10, 10))
+	target.Clear(color.White)
+
+	dr := imdraw.New(nil)
+	dr.Color = pixel.RGB(1, 0, 0).Mul(pixel.Alpha(0.5))
+	dr.Push(pixel.V(0, 0), pixel.V(10, 10))
+	dr.Rectangle(0)
+	dr.Draw(target)
+
+	assert.Equal(t, color.RGBA{255, 128, 128, 255}, target.Image().RGBAAt(5, 5))
+}
+
+func TestOffscreen_Draw(t *testing.T) {
+	src := window.NewOffscreen(pixel.R(0, 0, 10, 10))
+	src.Clear(color.RGBA{0, 0, 255, 255})
+
+	dst := window.NewOffscreen(pixel.R(0, 0, 40, 40))
+	dst.Clear(color.Black)
+	src.Draw(dst, pixel.IM.Moved(pixel.V(25, 15)))
+
+	img := dst.Image()
+	assert.Equal(t, color.RGBA{0, 0, 255, 255}, img.RGBAAt(20, 20))
+	assert.Equal(t, color.RGBA{0, 0, 255, 255}, img.RGBAAt(29, 29))
+	assert.Equal(t, color.RGBA{0, 0, 0, 255}, img.RGBAAt(19, 20))
+	assert.Equal(t, color.RGBA{0, 0, 0, 255}, img.RGBAAt(30, 20))
+}
+
+func TestOffscreen_Grid(t *testing.T) {
+	target := window.NewOffscreen(pixel.R(0, 0, 200, 100))
+	ctx := window.NewContext(target, target.Bounds(), nil)
+
+	grid := (&window.Grid{Columns: []float64{1, 1}}).
+		With(window.Cell{Drawer: &FillDrawer{Color: color.White}})
+	grid.Initialize(nil, ctx)
+
+	target.Clear(color.Black)
+	grid.Draw(nil, ctx)
+
+	img := target.Image()
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, img.RGBAAt(50, 50))
+	assert.Equal(t, color.RGBA{0, 0, 0, 255}, img.RGBAAt(150, 50))
+}
+
+// FillDrawer fills its whole drawing area with a color.
+type FillDrawer struct {
+	Color color.Color
+}
+
+func (d *FillDrawer) Initialize(w *ecs.World, ctx *window.Context) {}
+
+func (d *FillDrawer) Update(w *ecs.World) {}
+
+func (d *FillDrawer) UpdateInputs(w *ecs.World, ctx *window.Context) {}
+
+func (d *FillDrawer) Draw(w *ecs.World, ctx *window.Context) {
+	dr := imdraw.New(nil)
+	dr.Color = d.Color
+	dr.Push(pixel.V(0, 0), pixel.V(ctx.Bounds.W(), ctx.Bounds.H()))
+	dr.Rectangle(0)
+	dr.Draw(ctx)
+}
@@ -0,0 +1,36 @@
+package window
+
+import "image/color"
+
+// OkabeItoPalette creates the colorblind-safe palette by Okabe and Ito,
+// distinguishable with all common forms of color vision deficiency.
+// Black is omitted, as it is the background of dark themes.
+// It is the default palette of [DarkTheme] and [LightTheme].
+func OkabeItoPalette() []color.Color {
+	return []color.Color{
+		color.RGBA{0, 114, 178, 255},   // Blue
+		color.RGBA{230, 159, 0, 255},   // Orange
+		color.RGBA{0, 158, 115, 255},   // Bluish green
+		color.RGBA{213, 94, 0, 255},    // Vermillion
+		color.RGBA{86, 180, 233, 255},  // Sky blue
+		color.RGBA{204, 121, 167, 255}, // Reddish purple
+		color.RGBA{240, 228, 66, 255},  // Yellow
+	}
+}
+
+// TableauPalette creates the Tableau 10 palette,
+// with ten colors that are mostly distinguishable with color vision deficiency.
+func TableauPalette() []color.Color {
+	return []color.Color{
+		color.RGBA{78, 121, 167, 255},  // Blue
+		color.RGBA{242, 142, 43, 255},  // Orange
+		color.RGBA{225, 87, 89, 255},   // Red
+		color.RGBA{118, 183, 178, 255}, // Teal
+		color.RGBA{89, 161, 79, 255},   // Green
+		color.RGBA{237, 201, 72, 255},  // Yellow
+		color.RGBA{176, 122, 161, 255}, // Purple
+		color.RGBA{255, 157, 167, 255}, // Pink
+		color.RGBA{156, 117, 95, 255},  // Brown
+		color.RGBA{186, 176, 172, 255}, // Gray
+	}
+}
@@ -0,0 +1,278 @@
+package window
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/mlange-42/arche-model/resource"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+	xdraw "golang.org/x/image/draw"
+)
+
+// RecordFormat is the output format of a [Recorder].
+type RecordFormat uint8
+
+const (
+	// RecordPNG writes each frame to a numbered PNG file.
+	RecordPNG RecordFormat = iota
+	// RecordGIF writes frames into an animated GIF file.
+	RecordGIF
+	// RecordAVI writes frames into a Motion-JPEG AVI video file.
+	RecordAVI
+)
+
+// Recorder drawer for recording the frames of a [Window].
+//
+// Captures the composited frame every Interval draw steps,
+// and writes it to numbered PNG files, an animated GIF, or a Motion-JPEG AVI video.
+// Videos are encoded in pure Go, so no external tools like ffmpeg are required.
+// The Recorder should be added as the last drawer of a window, so that it captures everything drawn before it.
+// When used in a layout like [Grid], it captures only the drawing area it is assigned to.
+//
+// File names consist of the Prefix, the model tick and the number of the frame, e.g. frame_000120_00005.png.
+// Frames are numbered consecutively over all recordings of the recorder,
+// so that frames captured while the model is paused do not overwrite each other.
+// If the world contains no resource of type [github.com/mlange-42/arche-model/resource.Tick],
+// the number of draw steps since initialization is used instead of the tick.
+// For GIFs and videos, the tick and number of the first frame are used.
+// Frames are streamed to the file, which is completed when recording is stopped, or when the window is closed.
+// If the window is resized while recording a GIF or video, frames are scaled to the size of the first frame.
+// If a frame can't be written, the error is logged and recording is stopped.
+//
+// Recording is started and stopped by pressing R, remappable via the action "recorder.toggle" (see [Bindings]).
+// MaxFrames and Interval apply to each recording separately.
+// While recording, a red dot is shown in the top right corner of the window.
+// It is not part of the recorded frames.
+type Recorder struct {
+	Format    RecordFormat // Output format. Optional, default PNG.
+	Directory string       // Output directory. Optional, default current working directory.
+	Prefix    string       // Prefix for file names. Optional, default "frame".
+	Interval  int          // Interval for capturing frames, in draw steps. Optional, default 1.
+	MaxFrames int          // Maximum number of frames to record. Zero means unlimited. Optional.
+	Recording bool         // Whether recording is active. Set to true to start recording immediately.
+	GifDelay  int          // Delay between GIF frames, in 100ths of a second. Optional, default 4.
+	FrameRate int          // Frame rate of AVI videos, in frames per second. Optional, default 25.
+	Quality   int          // JPEG quality of AVI videos, from 1 to 100. Optional, default 90.
+	tickRes   generic.Resource[resource.Tick]
+	drawer    imdraw.IMDraw
+	frame     *image.RGBA
+	scaled    *image.RGBA
+	gifFile   *os.File
+	gif       *gifWriter
+	aviFile   *os.File
+	avi       *aviWriter
+	frames    int
+	total     int
+	step      int64
+	draws     int64
+	toggle    *Action
+}
+
+// Initialize the drawer.
+func (r *Recorder) Initialize(w *ecs.World, ctx *Context) {
+	if r.Prefix == "" {
+		r.Prefix = "frame"
+	}
+	if r.Interval <= 0 {
+		r.Interval = 1
+	}
+	if r.GifDelay <= 0 {
+		r.GifDelay = 4
+	}
+	if r.FrameRate <= 0 {
+		r.FrameRate = 25
+	}
+	if r.Quality <= 0 {
+		r.Quality = 90
+	}
+	if r.Format > RecordAVI {
+		panic(fmt.Sprintf("unknown record format %d", r.Format))
+	}
+	if r.Directory != "" {
+		if err := os.MkdirAll(r.Directory, os.ModePerm); err != nil {
+			panic(err)
+		}
+	}
+
+	r.toggle = ctx.Bindings.Register("recorder.toggle", pixel.KeyR, "Start or stop recording")
+
+	r.tickRes = generic.NewResource[resource.Tick](w)
+	r.drawer = *imdraw.New(nil)
+	r.frames = 0
+	r.total = 0
+	r.step = 0
+	r.draws = 0
+}
+
+// Update the drawer.
+func (r *Recorder) Update(w *ecs.World) {}
+
+// UpdateInputs handles input events of the previous frame update.
+func (r *Recorder) UpdateInputs(w *ecs.World, ctx *Context) {
+	if ctx.JustPressed(r.toggle.Key) {
+		if r.Recording {
+			r.Stop()
+		} else {
+			r.Start()
+		}
+	}
+}
+
+// Draw the drawer.
+func (r *Recorder) Draw(w *ecs.World, ctx *Context) {
+	if r.Recording && r.step%int64(r.Interval) == 0 {
+		r.frame = targetImage(ctx.Target, r.frame)
+		if r.frame == nil {
+			panic("recorder requires an OpenGL window or canvas, or an offscreen target as drawing target")
+		}
+		if err := r.capture(r.frame); err != nil {
+			r.logError(err)
+			r.Stop()
+		} else if r.MaxFrames > 0 && r.frames >= r.MaxFrames {
+			r.Stop()
+		}
+	}
+	r.step++
+	r.draws++
+
+	if r.Recording {
+		dr := &r.drawer
+		dr.Color = color.RGBA{220, 0, 0, 255}
+		dr.Push(pixel.V(ctx.Bounds.Max.X-15, ctx.Bounds.Max.Y-15))
+		dr.Circle(6, 0)
+		dr.Reset()
+		dr.Draw(ctx)
+		dr.Clear()
+	}
+}
+
+// Finalize the drawer. Stops recording.
+func (r *Recorder) Finalize(w *ecs.World) {
+	r.Stop()
+}
+
+// Shortcuts returns the recorder's keyboard shortcuts.
+func (r *Recorder) Shortcuts() []Shortcut {
+	return []Shortcut{r.toggle.Shortcut()}
+}
+
+// Start recording.
+// Resets the frame count and interval of the recording.
+func (r *Recorder) Start() {
+	r.Recording = true
+	r.frames = 0
+	r.step = 0
+}
+
+// Stop recording.
+// Completes the GIF or video file if recording in the respective format.
+// Errors are logged.
+func (r *Recorder) Stop() {
+	r.Recording = false
+	if r.gif != nil {
+		if err := r.gif.Close(); err != nil {
+			r.logError(err)
+		}
+		if err := r.gifFile.Close(); err != nil {
+			r.logError(err)
+		}
+		r.gif = nil
+		r.gifFile = nil
+	}
+	if r.avi != nil {
+		if err := r.avi.Close(); err != nil {
+			r.logError(err)
+		}
+		if err := r.aviFile.Close(); err != nil {
+			r.logError(err)
+		}
+		r.avi = nil
+		r.aviFile = nil
+	}
+}
+
+func (r *Recorder) capture(img *image.RGBA) error {
+	tick := r.tick()
+	num := r.total
+	r.frames++
+	r.total++
+
+	switch r.Format {
+	case RecordGIF:
+		if r.gif == nil {
+			file, err := r.create(tick, num, "gif")
+			if err != nil {
+				return err
+			}
+			r.gifFile = file
+			r.gif, err = newGifWriter(r.gifFile, img.Rect.Dx(), img.Rect.Dy(), r.GifDelay)
+			if err != nil {
+				r.gifFile.Close()
+				r.gifFile = nil
+				return err
+			}
+		}
+		return r.gif.WriteFrame(r.fit(img, image.Pt(r.gif.width, r.gif.height)))
+	case RecordAVI:
+		if r.avi == nil {
+			file, err := r.create(tick, num, "avi")
+			if err != nil {
+				return err
+			}
+			r.aviFile = file
+			r.avi, err = newAviWriter(r.aviFile, img.Rect.Dx(), img.Rect.Dy(), r.FrameRate, r.Quality)
+			if err != nil {
+				r.aviFile.Close()
+				r.aviFile = nil
+				return err
+			}
+		}
+		return r.avi.WriteFrame(r.fit(img, image.Pt(r.avi.width, r.avi.height)))
+	default:
+		file, err := r.create(tick, num, "png")
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		return png.Encode(file, img)
+	}
+}
+
+// fit scales the image to the given size, if it differs.
+// Used for keeping the size of GIF and video frames constant when the window is resized.
+func (r *Recorder) fit(img *image.RGBA, size image.Point) *image.RGBA {
+	if img.Rect.Size() == size {
+		return img
+	}
+	if r.scaled == nil || r.scaled.Rect.Size() != size {
+		r.scaled = image.NewRGBA(image.Rectangle{Max: size})
+	}
+	xdraw.ApproxBiLinear.Scale(r.scaled, r.scaled.Rect, img, img.Rect, xdraw.Src, nil)
+	return r.scaled
+}
+
+// create a file for the given tick, frame number and extension.
+func (r *Recorder) create(tick int64, num int, ext string) (*os.File, error) {
+	path := filepath.Join(r.Directory, fmt.Sprintf("%s_%06d_%05d.%s", r.Prefix, tick, num, ext))
+	return os.Create(path)
+}
+
+func (r *Recorder) logError(err error) {
+	log.Printf("ERROR: recorder: %s", err)
+}
+
+// tick returns the current model tick, or the number of draw steps if there is no tick resource.
+func (r *Recorder) tick() int64 {
+	if r.tickRes.Has() {
+		return r.tickRes.Get().Tick
+	}
+	return r.draws
+}
@@ -0,0 +1,80 @@
+package window
+
+import (
+	"image"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/mlange-42/arche-model/resource"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/mlange-42/arche/generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRecorder(format RecordFormat, dir string) *Recorder {
+	w := ecs.NewWorld()
+	return &Recorder{
+		Format:    format,
+		Directory: dir,
+		Prefix:    "frame",
+		Interval:  1,
+		GifDelay:  4,
+		FrameRate: 25,
+		Quality:   90,
+		tickRes:   generic.NewResource[resource.Tick](&w),
+		Recording: true,
+		drawer:    *imdraw.New(nil),
+	}
+}
+
+func TestRecorder_Resize(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, format := range []RecordFormat{RecordGIF, RecordAVI} {
+		r := newTestRecorder(format, dir)
+		assert.Nil(t, r.capture(image.NewRGBA(image.Rect(0, 0, 40, 30))))
+		assert.Nil(t, r.capture(image.NewRGBA(image.Rect(0, 0, 80, 20))))
+		r.Stop()
+	}
+
+	file, err := os.Open(filepath.Join(dir, "frame_000000_00000.gif"))
+	assert.Nil(t, err)
+	defer file.Close()
+	anim, err := gif.DecodeAll(file)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(anim.Image))
+	assert.Equal(t, image.Rect(0, 0, 40, 30), anim.Image[1].Rect)
+
+	_, err = os.Stat(filepath.Join(dir, "frame_000000_00000.avi"))
+	assert.Nil(t, err)
+}
+
+func TestRecorder_Error(t *testing.T) {
+	r := newTestRecorder(RecordPNG, filepath.Join(t.TempDir(), "missing"))
+	assert.NotNil(t, r.capture(image.NewRGBA(image.Rect(0, 0, 40, 30))))
+}
+
+func TestRecorder_Numbering(t *testing.T) {
+	dir := t.TempDir()
+	r := newTestRecorder(RecordPNG, dir)
+	target := NewOffscreen(pixel.R(0, 0, 40, 30))
+	ctx := NewContext(target, target.Bounds(), nil)
+
+	r.Draw(nil, ctx)
+	r.Draw(nil, ctx)
+	r.Stop()
+	r.Draw(nil, ctx)
+	r.Start()
+	r.Draw(nil, ctx)
+
+	files, err := filepath.Glob(filepath.Join(dir, "frame_*.png"))
+	assert.Nil(t, err)
+	for i := range files {
+		files[i] = filepath.Base(files[i])
+	}
+	assert.Equal(t, []string{"frame_000000_00000.png", "frame_000001_00001.png", "frame_000003_00002.png"}, files)
+}
@@ -0,0 +1,161 @@
+package window_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleRecorder() {
+	// Create a new model.
+	m := model.New()
+
+	// Limit the the simulation speed.
+	m.TPS = 30
+
+	// Create a window with a drawer, and a Recorder as last drawer.
+	m.AddUISystem((&window.Window{}).
+		With(
+			&RectDrawer{},
+			&window.Recorder{
+				Format:    window.RecordGIF,
+				Directory: os.TempDir(),
+				Interval:  5,
+			},
+		))
+
+	// Add a termination system that ends the simulation.
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+
+	// Run the simulation.
+	// Due to the use of the OpenGL UI system, the model must be run via [window.Run].
+	// Comment out the code line above, and uncomment the next line to run this example stand-alone.
+
+	// window.Run(m)
+
+	// Output:
+}
+
+func TestRecorder_PNG(t *testing.T) {
+	dir := t.TempDir()
+
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	m.AddUISystem((&window.Window{}).
+		With(
+			&RectDrawer{},
+			&window.Recorder{
+				Directory: dir,
+				Interval:  2,
+				MaxFrames: 5,
+				Recording: true,
+			},
+		))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 20,
+	})
+	m.Run()
+
+	files, err := filepath.Glob(filepath.Join(dir, "frame_*.png"))
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(files))
+}
+
+func TestRecorder_GIF(t *testing.T) {
+	dir := t.TempDir()
+
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	m.AddUISystem((&window.Window{}).
+		With(
+			&RectDrawer{},
+			&window.Recorder{
+				Format:    window.RecordGIF,
+				Directory: dir,
+				Prefix:    "anim",
+				MaxFrames: 5,
+				Recording: true,
+			},
+		))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 20,
+	})
+	m.Run()
+
+	files, err := filepath.Glob(filepath.Join(dir, "anim_*.gif"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(files))
+}
+
+func TestRecorder_AVI(t *testing.T) {
+	dir := t.TempDir()
+
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	m.AddUISystem((&window.Window{}).
+		With(
+			&RectDrawer{},
+			&window.Recorder{
+				Format:    window.RecordAVI,
+				Directory: dir,
+				Prefix:    "video",
+				FrameRate: 10,
+				Quality:   75,
+				MaxFrames: 5,
+				Recording: true,
+			},
+		))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 20,
+	})
+	m.Run()
+
+	files, err := filepath.Glob(filepath.Join(dir, "video_*.avi"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(files))
+}
+
+func TestRecorder_Finalize(t *testing.T) {
+	dir := t.TempDir()
+
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	m.AddUISystem((&window.Window{}).
+		With(
+			&RectDrawer{},
+			&window.Recorder{
+				Format:    window.RecordGIF,
+				Directory: dir,
+				Recording: true,
+			},
+		))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	m.Run()
+
+	files, err := filepath.Glob(filepath.Join(dir, "frame_*.gif"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(files))
+}
@@ -0,0 +1,103 @@
+package window
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/mlange-42/arche/ecs"
+)
+
+// VectorExporter is an optional interface for a [Drawer] that can export its content as vector graphics.
+// It is used for screenshots of a [Window], in addition to the PNG image of the entire window.
+// Only drawers that are currently shown are exported, e.g. only the selected tab of [Tabs].
+//
+// The format of the exported file is determined by the extension of the given path, e.g. .svg or .pdf.
+type VectorExporter interface {
+	// ExportVector writes the drawer's content to a file.
+	ExportVector(w *ecs.World, ctx *Context, path string) error
+}
+
+// Screenshot requests a screenshot of the window, taken on the next re-draw.
+// See [Window] for details.
+func (w *Window) Screenshot() {
+	w.takeScreenshot = true
+}
+
+// screenshot saves the current frame, as well as vector graphics of drawers implementing [VectorExporter].
+// Errors are logged, and files that can be written are still saved.
+func (w *Window) screenshot(world *ecs.World) {
+	tick := w.tick()
+
+	if w.ScreenshotDir != "" {
+		if err := os.MkdirAll(w.ScreenshotDir, os.ModePerm); err != nil {
+			logScreenshotError(err)
+			return
+		}
+	}
+	base := filepath.Join(w.ScreenshotDir, fmt.Sprintf("%s_%06d_%05d", fileName(w.Title), tick, w.shots))
+	w.shots++
+
+	if err := writePng(base+".png", targetImage(w.context.Target, nil)); err != nil {
+		logScreenshotError(err)
+	}
+
+	exportVectors(world, w.Drawers, w.context, base, w.VectorFormat, 0)
+}
+
+// writePng writes an image to a PNG file.
+func writePng(path string, img image.Image) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func logScreenshotError(err error) {
+	log.Printf("ERROR: screenshot: %s", err)
+}
+
+// exportVectors exports all drawers implementing [VectorExporter], including drawers nested in containers like [Grid].
+// Children of containers that are currently not shown, like unselected tabs, are skipped.
+// Files are numbered, starting after the given count. Returns the count of exported drawers.
+// Export errors are logged, and the remaining drawers are still exported.
+func exportVectors(world *ecs.World, drawers []Drawer, ctx *Context, base string, ext string, count int) int {
+	for _, d := range drawers {
+		if p, ok := d.(parent); ok {
+			for i, child := range p.children() {
+				if !p.active(i) {
+					continue
+				}
+				count = exportVectors(world, []Drawer{child}, p.childContext(i, ctx), base, ext, count)
+			}
+		}
+		if e, ok := d.(VectorExporter); ok {
+			count++
+			path := fmt.Sprintf("%s_%d.%s", base, count, ext)
+			if err := e.ExportVector(world, ctx, path); err != nil {
+				logScreenshotError(fmt.Errorf("%s: %w", path, err))
+			}
+		}
+	}
+	return count
+}
+
+// fileName replaces all characters except letters, digits and dashes by underscores.
+func fileName(title string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' {
+			return r
+		}
+		return '_'
+	}, title)
+}
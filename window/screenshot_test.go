@@ -0,0 +1,43 @@
+package window
+
+import (
+	"errors"
+	"image"
+	"path/filepath"
+	"testing"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportVectors_Error(t *testing.T) {
+	a, b := &failingExporter{}, &failingExporter{}
+	ctx := NewContext(nil, pixel.R(0, 0, 100, 100), nil)
+
+	count := exportVectors(nil, []Drawer{a, b}, ctx, filepath.Join(t.TempDir(), "shot"), "svg", 0)
+
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 1, a.calls)
+	assert.Equal(t, 1, b.calls)
+}
+
+func TestWritePng_Error(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "shot.png")
+	assert.NotNil(t, writePng(path, image.NewRGBA(image.Rect(0, 0, 10, 10))))
+}
+
+// failingExporter is a drawer that fails to export vector graphics.
+type failingExporter struct {
+	calls int
+}
+
+func (d *failingExporter) Initialize(w *ecs.World, ctx *Context)   {}
+func (d *failingExporter) Update(w *ecs.World)                     {}
+func (d *failingExporter) UpdateInputs(w *ecs.World, ctx *Context) {}
+func (d *failingExporter) Draw(w *ecs.World, ctx *Context)         {}
+
+func (d *failingExporter) ExportVector(w *ecs.World, ctx *Context, path string) error {
+	d.calls++
+	return errors.New("export failed")
+}
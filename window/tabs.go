@@ -0,0 +1,259 @@
+package window
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/ext/imdraw"
+	"github.com/gopxl/pixel/v2/ext/text"
+	"github.com/mlange-42/arche/ecs"
+)
+
+const tabBarHeight = 20.0
+
+// Tab of a [Tabs] drawer.
+type Tab struct {
+	Drawer Drawer // Drawer of the tab.
+	Title  string // Title of the tab. Optional, default the drawer's type name.
+}
+
+// Tabs drawer for showing one of multiple drawers at a time.
+//
+// Tabs are selected by clicking on the tab bar at the top, or using the number keys 1 to 9.
+// Number keys can be remapped via the actions "tabs.1" to "tabs.9" (see [Bindings]).
+//
+// All drawers are updated, but only the selected drawer receives user input and is drawn.
+// The selected drawer is drawn into a canvas below the tab bar, with the coordinate origin at its bottom left corner.
+// Calls to [Finalizer] and [Resizer] are forwarded to all drawers, calls to [Hitter] only to the selected one.
+type Tabs struct {
+	Tabs     []Tab // Tabs to choose from.
+	Selected int   // Index of the selected tab.
+	bounds   pixel.Rect
+	context  *Context
+	canvas   canvas
+	drawer   imdraw.IMDraw
+	text     *text.Text
+	keys     []*Action
+}
+
+// With adds one or more [Tab] instances.
+func (t *Tabs) With(tabs ...Tab) *Tabs {
+	t.Tabs = append(t.Tabs, tabs...)
+	return t
+}
+
+// Initialize the drawer.
+func (t *Tabs) Initialize(w *ecs.World, ctx *Context) {
+	if t.Selected < 0 || t.Selected >= len(t.Tabs) {
+		t.Selected = 0
+	}
+
+	t.bounds = ctx.Bounds
+	content := t.ContentBounds(ctx.Bounds)
+	t.canvas = newCanvas(ctx.Target, pixel.R(0, 0, content.W(), content.H()))
+	t.context = ctx.child(t.canvas, content)
+
+	t.drawer = *imdraw.New(nil)
+	t.text = text.New(pixel.V(0, 0), ctx.Font)
+
+	t.keys = make([]*Action, 0, 9)
+	for i := range t.Tabs {
+		tab := &t.Tabs[i]
+		if tab.Title == "" {
+			tab.Title = typeName(tab.Drawer)
+		}
+		if i < 9 {
+			t.keys = append(t.keys, ctx.Bindings.Register(fmt.Sprintf("tabs.%d", i+1), pixel.Key1+pixel.Button(i), fmt.Sprintf("Show tab %d", i+1)))
+		}
+		tab.Drawer.Initialize(w, t.context)
+	}
+}
+
+// Update the drawer.
+func (t *Tabs) Update(w *ecs.World) {
+	for _, tab := range t.Tabs {
+		tab.Drawer.Update(w)
+	}
+}
+
+// UpdateInputs handles input events of the previous frame update.
+func (t *Tabs) UpdateInputs(w *ecs.World, ctx *Context) {
+	if ctx.Bounds != t.bounds {
+		t.Resize(w, ctx)
+	}
+	for i, key := range t.keys {
+		if ctx.JustPressed(key.Key) {
+			t.Selected = i
+		}
+	}
+
+	pos := ctx.MousePosition()
+	child := t.updateContext(ctx)
+	if t.barBounds(ctx.Bounds).Contains(pos) {
+		if ctx.JustPressed(pixel.MouseButtonLeft) {
+			for i := range t.Tabs {
+				if t.tabBounds(i, ctx.Bounds).Contains(pos) {
+					t.Selected = i
+				}
+			}
+		}
+		child.Input = filteredInput{Input: child.Input, mouse: false, keys: true}
+	}
+
+	if len(t.Tabs) > 0 {
+		t.Tabs[t.Selected].Drawer.UpdateInputs(w, child)
+	}
+}
+
+// Draw the drawer.
+func (t *Tabs) Draw(w *ecs.World, ctx *Context) {
+	if ctx.Bounds != t.bounds {
+		t.Resize(w, ctx)
+	}
+
+	if len(t.Tabs) > 0 {
+		child := t.updateContext(ctx)
+		t.canvas.Clear(color.Transparent)
+		t.Tabs[t.Selected].Drawer.Draw(w, child)
+		t.canvas.Draw(ctx, pixel.IM.Moved(t.ContentBounds(ctx.Bounds).Center()))
+	}
+
+	dr := &t.drawer
+	dr.Color = ctx.Theme.Background
+	bar := t.barBounds(ctx.Bounds)
+	dr.Push(bar.Min, bar.Max)
+	dr.Rectangle(0)
+	dr.Reset()
+
+	for i := range t.Tabs {
+		b := t.tabBounds(i, ctx.Bounds)
+		if i == t.Selected {
+			dr.Color = ctx.Theme.Accent
+		} else {
+			dr.Color = ctx.Theme.Grid
+		}
+		dr.Push(b.Min, b.Max)
+		dr.Rectangle(0)
+		dr.Reset()
+	}
+	dr.Draw(ctx)
+	dr.Clear()
+
+	for i, tab := range t.Tabs {
+		b := t.tabBounds(i, ctx.Bounds)
+		t.text.Clear()
+		t.text.Color = ctx.Theme.Foreground
+		fmt.Fprint(t.text, tab.Title)
+		t.text.Draw(ctx, pixel.IM.Moved(pixel.V(b.Min.X+8, b.Min.Y+6)))
+	}
+}
+
+// Hit checks whether the given position is on the tab bar, or on an interactive region of the selected tab.
+func (t *Tabs) Hit(ctx *Context, pos pixel.Vec) bool {
+	if t.barBounds(ctx.Bounds).Contains(pos) {
+		return true
+	}
+	if len(t.Tabs) == 0 {
+		return false
+	}
+	content := t.ContentBounds(ctx.Bounds)
+	return hit(t.Tabs[t.Selected].Drawer, t.updateContext(ctx), pos.Sub(content.Min))
+}
+
+// Resize the drawing area of the tabs.
+func (t *Tabs) Resize(w *ecs.World, ctx *Context) {
+	t.bounds = ctx.Bounds
+	child := t.updateContext(ctx)
+	if t.canvas.Bounds() == child.Bounds {
+		return
+	}
+	t.canvas.SetBounds(child.Bounds)
+	for _, tab := range t.Tabs {
+		resize(w, tab.Drawer, child)
+	}
+}
+
+// Finalize the drawers of all tabs.
+func (t *Tabs) Finalize(w *ecs.World) {
+	for _, tab := range t.Tabs {
+		finalize(w, tab.Drawer)
+	}
+}
+
+// Shortcuts returns the keyboard and mouse shortcuts for selecting tabs.
+func (t *Tabs) Shortcuts() []Shortcut {
+	shortcuts := make([]Shortcut, 0, len(t.keys)+1)
+	for _, key := range t.keys {
+		shortcuts = append(shortcuts, key.Shortcut())
+	}
+	return append(shortcuts, Shortcut{Keys: "Mouse click", Description: "Show tab"})
+}
+
+// ContentBounds calculates the bounds of the drawing area below the tab bar,
+// for the given bounds of the entire drawer.
+func (t *Tabs) ContentBounds(bounds pixel.Rect) pixel.Rect {
+	return pixel.R(
+		math.Round(bounds.Min.X),
+		math.Round(bounds.Min.Y),
+		math.Round(bounds.Max.X),
+		math.Round(bounds.Max.Y-tabBarHeight),
+	)
+}
+
+// children returns the drawers of all tabs.
+func (t *Tabs) children() []Drawer {
+	drawers := make([]Drawer, len(t.Tabs))
+	for i, tab := range t.Tabs {
+		drawers[i] = tab.Drawer
+	}
+	return drawers
+}
+
+// childContext returns the context of the tabs' drawers.
+func (t *Tabs) childContext(index int, ctx *Context) *Context {
+	return t.updateContext(ctx)
+}
+
+// active returns whether the tab with the given index is selected.
+func (t *Tabs) active(index int) bool {
+	return index == t.Selected
+}
+
+// updateContext updates the context of the drawers to the current bounds.
+func (t *Tabs) updateContext(ctx *Context) *Context {
+	content := t.ContentBounds(ctx.Bounds)
+	t.context.Input = offsetInput{Input: ctx.Input, offset: content.Min}
+	t.context.Bounds = pixel.R(0, 0, content.W(), content.H())
+	return t.context
+}
+
+// barBounds calculates the bounds of the tab bar.
+func (t *Tabs) barBounds(bounds pixel.Rect) pixel.Rect {
+	return pixel.R(bounds.Min.X, t.ContentBounds(bounds).Max.Y, bounds.Max.X, bounds.Max.Y)
+}
+
+// tabBounds calculates the bounds of the tab with the given index in the tab bar.
+func (t *Tabs) tabBounds(index int, bounds pixel.Rect) pixel.Rect {
+	bar := t.barBounds(bounds)
+	x := bar.Min.X
+	for i := 0; i < index; i++ {
+		x += t.tabWidth(i) + 1
+	}
+	return pixel.R(x, bar.Min.Y, x+t.tabWidth(index), bar.Max.Y)
+}
+
+// tabWidth calculates the width of the tab with the given index, from its title.
+func (t *Tabs) tabWidth(index int) float64 {
+	return math.Round(t.text.BoundsOf(t.Tabs[index].Title).W() + 16)
+}
+
+// typeName returns the type name of a drawer, or of the drawer wrapped by [Adapt], without pointer indicator.
+func typeName(d Drawer) string {
+	if a, ok := d.(*adapter); ok {
+		return strings.TrimPrefix(fmt.Sprintf("%T", a.drawer), "*")
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", d), "*")
+}
@@ -0,0 +1,147 @@
+package window_test
+
+import (
+	"testing"
+
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/gopxl/pixel/v2/backends/opengl"
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleTabs() {
+	// Create a new model.
+	m := model.New()
+
+	// Limit the the simulation speed.
+	m.TPS = 30
+
+	// Create tabs, showing one drawer at a time.
+	tabs := (&window.Tabs{}).With(
+		window.Tab{Drawer: &RectDrawer{}, Title: "First"},
+		window.Tab{Drawer: &RectDrawer{}, Title: "Second"},
+	)
+
+	// Add the tabs to a window.
+	// Further, add a drawer that can be shown or hidden by pressing H.
+	m.AddUISystem((&window.Window{}).With(
+		tabs,
+		&window.Toggle{Drawer: &RectDrawer{}, Key: pixel.KeyH},
+	))
+
+	// Add a termination system that ends the simulation.
+	m.AddSystem(&system.FixedTermination{
+		Steps: 100,
+	})
+
+	m.Run()
+
+	// Run the simulation.
+	// Due to the use of the OpenGL UI system, the model must be run via [window.Run].
+	// Comment out the code line above, and uncomment the next line to run this example stand-alone.
+
+	// window.Run(m)
+
+	// Output:
+}
+
+func TestTabs(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	hidden := LifecycleDrawer{}
+	tabs := (&window.Tabs{}).With(
+		window.Tab{Drawer: &RectDrawer{}},
+		window.Tab{Drawer: &hidden},
+	)
+	m.AddUISystem((&window.Window{}).With(tabs))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	m.Run()
+
+	assert.Equal(t, "window_test.RectDrawer", tabs.Tabs[0].Title)
+	assert.Equal(t, 1, hidden.Finalized)
+}
+
+func TestTabs_Inputs(t *testing.T) {
+	win, err := opengl.NewWindow(opengl.WindowConfig{Bounds: pixel.R(0, 0, 200, 100), Invisible: true})
+	assert.Nil(t, err)
+	defer win.Destroy()
+
+	m := model.New()
+	input := clickInput{}
+	ctx := window.NewContext(win, pixel.R(0, 0, 200, 100), &input)
+
+	first, second := ClickDrawer{}, ClickDrawer{}
+	tabs := (&window.Tabs{}).With(
+		window.Tab{Drawer: &first, Title: "A"},
+		window.Tab{Drawer: &second, Title: "B"},
+	)
+	tabs.Initialize(&m.World, ctx)
+	assert.Equal(t, pixel.R(0, 0, 200, 80), tabs.ContentBounds(ctx.Bounds))
+
+	input.mouse = pixel.V(50, 40)
+	tabs.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, 1, first.Clicks)
+	assert.Equal(t, 0, second.Clicks)
+
+	input.mouse = pixel.V(30, 90)
+	assert.True(t, tabs.Hit(ctx, input.mouse))
+	tabs.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, 1, tabs.Selected)
+	assert.Equal(t, 1, first.Clicks)
+	assert.Equal(t, 0, second.Clicks)
+
+	input.mouse = pixel.V(150, 40)
+	tabs.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, 1, second.Clicks)
+}
+
+func TestToggle(t *testing.T) {
+	m := model.New()
+	input := clickInput{}
+	ctx := window.NewContext(nil, pixel.R(0, 0, 200, 100), &input)
+
+	drawer := ClickDrawer{}
+	toggle := window.Toggle{Drawer: &drawer, Key: pixel.KeyH}
+	toggle.Initialize(&m.World, ctx)
+
+	assert.Equal(t, "window_test.ClickDrawer", toggle.Name)
+	assert.Equal(t, []window.Shortcut{{Keys: "H", Description: "Show or hide window_test.ClickDrawer"}}, toggle.Shortcuts())
+
+	input.mouse = pixel.V(150, 50)
+	toggle.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, 1, drawer.Clicks)
+	assert.True(t, toggle.Hit(ctx, input.mouse))
+
+	toggle.Hidden = true
+	toggle.UpdateInputs(&m.World, ctx)
+	assert.Equal(t, 1, drawer.Clicks)
+	assert.False(t, toggle.Hit(ctx, input.mouse))
+}
+
+func TestToggle_Unbound(t *testing.T) {
+	m := model.New()
+	ctx := window.NewContext(nil, pixel.R(0, 0, 200, 100), nil)
+
+	first := window.Toggle{Drawer: &ClickDrawer{}, Name: "first"}
+	second := window.Toggle{Drawer: &ClickDrawer{}, Name: "second"}
+	first.Initialize(&m.World, ctx)
+	second.Initialize(&m.World, ctx)
+
+	assert.Equal(t, 2, len(ctx.Bindings.Actions()))
+	for _, a := range ctx.Bindings.Actions() {
+		assert.False(t, a.Bound())
+	}
+	assert.Empty(t, ctx.Bindings.Conflicts())
+	assert.Nil(t, first.Shortcuts())
+
+	ctx.Bindings.Set("toggle.first", pixel.KeyB)
+	assert.Equal(t, []window.Shortcut{{Keys: "B", Description: "Show or hide first"}}, first.Shortcuts())
+	assert.Nil(t, second.Shortcuts())
+}
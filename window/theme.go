@@ -0,0 +1,87 @@
+package window
+
+import (
+	"image/color"
+
+	"github.com/gopxl/pixel/v2/ext/text"
+	"golang.org/x/image/colornames"
+)
+
+// Theme defines the colors and the font for drawing, shared by all drawers of a [Window].
+//
+// The theme of a window is passed to drawers via [Context].
+// Drawers should use its colors instead of hard-coded colors, except for colors with a specific meaning.
+// Built-in themes are created by [DarkTheme], [LightTheme] and [HighContrastTheme].
+type Theme struct {
+	Background color.Color   // Background of the window, plots and overlays.
+	Foreground color.Color   // Text, axes and other foreground elements.
+	Grid       color.Color   // Grid lines, outlines and inactive elements.
+	Accent     color.Color   // Highlights, selections and active elements.
+	Palette    []color.Color // Colors for data series and entities, used cyclically. See e.g. [OkabeItoPalette] and [TableauPalette].
+	Font       *text.Atlas   // Font for text. Optional, default a small bitmap font.
+}
+
+// DarkTheme creates a theme with light elements on a black background.
+func DarkTheme() *Theme {
+	return &Theme{
+		Background: colornames.Black,
+		Foreground: color.RGBA{220, 220, 220, 255},
+		Grid:       color.RGBA{90, 90, 90, 255},
+		Accent:     color.RGBA{255, 80, 60, 255},
+		Palette:    OkabeItoPalette(),
+	}
+}
+
+// LightTheme creates a theme with dark elements on a white background.
+// It is the default theme of a [Window].
+func LightTheme() *Theme {
+	return &Theme{
+		Background: colornames.White,
+		Foreground: colornames.Black,
+		Grid:       color.RGBA{190, 190, 190, 255},
+		Accent:     color.RGBA{220, 0, 0, 255},
+		Palette:    OkabeItoPalette(),
+	}
+}
+
+// HighContrastTheme creates a theme with saturated, bright elements on a black background.
+func HighContrastTheme() *Theme {
+	return &Theme{
+		Background: colornames.Black,
+		Foreground: colornames.White,
+		Grid:       color.RGBA{170, 170, 170, 255},
+		Accent:     colornames.Yellow,
+		Palette: []color.Color{
+			colornames.Yellow,
+			colornames.Cyan,
+			colornames.Magenta,
+			colornames.Lime,
+			colornames.White,
+			colornames.Orange,
+		},
+	}
+}
+
+// SeriesColor returns the palette color for the data series with the given index.
+// Indices beyond the length of the palette wrap around.
+// If the palette is empty, the [OkabeItoPalette] is used.
+func (t *Theme) SeriesColor(index int) color.Color {
+	palette := t.Palette
+	if len(palette) == 0 {
+		palette = OkabeItoPalette()
+	}
+	return palette[index%len(palette)]
+}
+
+// Overlay returns the background color with the given opacity, for overlays like the help screen or tooltips.
+func (t *Theme) Overlay(alpha uint8) color.Color {
+	return Fade(t.Background, alpha)
+}
+
+// Fade returns a color with the given opacity, as premultiplied RGBA.
+// The opacity of the given color is ignored.
+func Fade(c color.Color, alpha uint8) color.Color {
+	r, g, b, _ := c.RGBA()
+	a := uint32(alpha)
+	return color.RGBA{uint8(r >> 8 * a / 255), uint8(g >> 8 * a / 255), uint8(b >> 8 * a / 255), alpha}
+}
@@ -0,0 +1,62 @@
+package window_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
+	"github.com/mlange-42/arche-pixel/window"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleTheme() {
+	m := model.New()
+
+	// Create a Window with the dark theme, honored by all drawers.
+	win := (&window.Window{Theme: window.DarkTheme()}).
+		With(&RectDrawer{})
+
+	m.AddUISystem(win)
+	// Output:
+}
+
+func TestTheme(t *testing.T) {
+	for _, theme := range []*window.Theme{window.DarkTheme(), window.LightTheme(), window.HighContrastTheme()} {
+		assert.NotNil(t, theme.Background)
+		assert.NotNil(t, theme.Foreground)
+		assert.NotNil(t, theme.Grid)
+		assert.NotNil(t, theme.Accent)
+		assert.Greater(t, len(theme.Palette), 1)
+		assert.Equal(t, theme.Palette[1], theme.SeriesColor(len(theme.Palette)+1))
+	}
+
+	assert.Equal(t, 7, len(window.OkabeItoPalette()))
+	assert.Equal(t, 10, len(window.TableauPalette()))
+
+	empty := window.Theme{}
+	assert.Equal(t, window.OkabeItoPalette()[2], empty.SeriesColor(2))
+
+	light := window.LightTheme()
+	assert.Equal(t, color.RGBA{128, 128, 128, 128}, light.Overlay(128))
+	assert.Equal(t, color.RGBA{0, 0, 0, 200}, window.DarkTheme().Overlay(200))
+	assert.Equal(t, color.RGBA{0, 50, 100, 100}, window.Fade(color.RGBA{0, 128, 255, 255}, 100))
+}
+
+func TestWindow_Theme(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+
+	theme := window.HighContrastTheme()
+	theme.Font = window.GoFont(14)
+	drawer := FontDrawer{}
+	m.AddUISystem((&window.Window{Theme: theme}).
+		With((&window.Tabs{}).With(window.Tab{Drawer: &drawer})))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	m.Run()
+
+	assert.Equal(t, theme.Font, drawer.font)
+}
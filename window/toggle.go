@@ -0,0 +1,98 @@
+package window
+
+import (
+	pixel "github.com/gopxl/pixel/v2"
+	"github.com/mlange-42/arche/ecs"
+)
+
+// Toggle wraps a [Drawer] to show or hide it at runtime.
+//
+// The drawer is shown or hidden by pressing Key, or by setting Hidden.
+// The key can be remapped via the action "toggle.<Name>" (see [Bindings]).
+// The action is registered even if no Key is given, so that it can be bound by remapping.
+// Without a Key and without remapping, the action is unbound (see [Action.Bound]).
+// Hidden drawers are still updated, but receive no user input and are not drawn.
+//
+// Calls to [Finalizer] and [Resizer] are forwarded to the wrapped drawer, calls to [Hitter] only while it is shown.
+type Toggle struct {
+	Drawer Drawer       // The wrapped drawer.
+	Hidden bool         // Whether the drawer is hidden.
+	Key    pixel.Button // Key for showing and hiding the drawer. Mouse buttons are not supported. Optional, default unbound.
+	Name   string       // Name of the drawer, used for the key binding. Optional, default the drawer's type name.
+	toggle *Action
+}
+
+// Initialize the drawer.
+func (t *Toggle) Initialize(w *ecs.World, ctx *Context) {
+	if t.Name == "" {
+		t.Name = typeName(t.Drawer)
+	}
+	key := t.Key
+	if key <= pixel.MouseButton8 {
+		// Mouse buttons, including the zero value of Key, leave the action unbound.
+		key = pixel.UnknownButton
+	}
+	t.toggle = ctx.Bindings.Register("toggle."+t.Name, key, "Show or hide "+t.Name)
+	t.Drawer.Initialize(w, ctx)
+}
+
+// Update the drawer.
+func (t *Toggle) Update(w *ecs.World) {
+	t.Drawer.Update(w)
+}
+
+// UpdateInputs handles input events of the previous frame update.
+func (t *Toggle) UpdateInputs(w *ecs.World, ctx *Context) {
+	if t.toggle.Bound() && ctx.JustPressed(t.toggle.Key) {
+		t.Hidden = !t.Hidden
+		return
+	}
+	if !t.Hidden {
+		t.Drawer.UpdateInputs(w, ctx)
+	}
+}
+
+// Draw the drawer.
+func (t *Toggle) Draw(w *ecs.World, ctx *Context) {
+	if !t.Hidden {
+		t.Drawer.Draw(w, ctx)
+	}
+}
+
+// Hit checks for interactive regions of the wrapped drawer, if it is shown.
+func (t *Toggle) Hit(ctx *Context, pos pixel.Vec) bool {
+	return !t.Hidden && hit(t.Drawer, ctx, pos)
+}
+
+// Resize the wrapped drawer.
+func (t *Toggle) Resize(w *ecs.World, ctx *Context) {
+	resize(w, t.Drawer, ctx)
+}
+
+// Finalize the wrapped drawer.
+func (t *Toggle) Finalize(w *ecs.World) {
+	finalize(w, t.Drawer)
+}
+
+// Shortcuts returns the keyboard shortcut for showing and hiding the drawer.
+func (t *Toggle) Shortcuts() []Shortcut {
+	if !t.toggle.Bound() {
+		return nil
+	}
+	return []Shortcut{t.toggle.Shortcut()}
+}
+
+// children returns the wrapped drawer.
+func (t *Toggle) children() []Drawer {
+	return []Drawer{t.Drawer}
+}
+
+// childContext returns the context of the wrapped drawer, which is the toggle's context.
+func (t *Toggle) childContext(index int, ctx *Context) *Context {
+	return ctx
+}
+
+// active returns whether the wrapped drawer is shown.
+func (t *Toggle) active(index int) bool {
+	return !t.Hidden
+}
@@ -1,14 +1,89 @@
 package window
 
 import (
+	"image"
 	"math"
 
+	pixel "github.com/gopxl/pixel/v2"
 	"github.com/gopxl/pixel/v2/backends/opengl"
 )
 
-// Scale calculates the drawing scale for fitting a source region into a window's canvas.
-func Scale(win *opengl.Window, srcWidth, srcHeight float64) float64 {
-	winWidth, winHeight := win.Canvas().Bounds().W(), win.Canvas().Bounds().H()
-	scX, scY := winWidth/float64(srcWidth), winHeight/float64(srcHeight)
+// Scale calculates the drawing scale for fitting a source region into the bounds of a drawing context.
+func Scale(ctx *Context, srcWidth, srcHeight float64) float64 {
+	scX, scY := ctx.Bounds.W()/float64(srcWidth), ctx.Bounds.H()/float64(srcHeight)
 	return math.Min(scX, scY)
 }
+
+// canvas is an intermediate drawing target, used by containers like [Grid] and [Camera].
+type canvas interface {
+	Target
+	Bounds() pixel.Rect
+	SetBounds(bounds pixel.Rect)
+	Draw(t pixel.Target, matrix pixel.Matrix)
+}
+
+// newCanvas creates an intermediate drawing target that can be drawn to the given target.
+// Creates an OpenGL canvas for OpenGL targets, and an [Offscreen] target otherwise.
+func newCanvas(t Target, bounds pixel.Rect) canvas {
+	switch t.(type) {
+	case *opengl.Window, *opengl.Canvas:
+		return opengl.NewCanvas(bounds)
+	}
+	return NewOffscreen(bounds)
+}
+
+// targetImage copies the content of a drawing target into an image.
+// Re-uses the given image if it has the correct size.
+// Returns nil if the target is not an OpenGL window or canvas, or an [Offscreen] target.
+func targetImage(t Target, img *image.RGBA) *image.RGBA {
+	var c *opengl.Canvas
+	switch tt := t.(type) {
+	case *opengl.Window:
+		c = tt.Canvas()
+	case *opengl.Canvas:
+		c = tt
+	case *Offscreen:
+		return offscreenImage(tt, img)
+	default:
+		return nil
+	}
+
+	pixels := c.Pixels()
+	bounds := c.Bounds()
+	width, height := int(bounds.W()), int(bounds.H())
+
+	if img == nil || img.Rect.Dx() != width || img.Rect.Dy() != height {
+		img = image.NewRGBA(image.Rect(0, 0, width, height))
+	}
+	flipRows(pixels, img.Pix, width*4)
+	return img
+}
+
+// offscreenImage copies the content of an [Offscreen] target into an image.
+// Re-uses the given image if it has the correct size.
+func offscreenImage(o *Offscreen, img *image.RGBA) *image.RGBA {
+	width, height := o.pixels.Stride, 0
+	if width > 0 {
+		height = len(o.pixels.Pix) / width
+	}
+	if img == nil || img.Rect.Dx() != width || img.Rect.Dy() != height {
+		img = image.NewRGBA(image.Rect(0, 0, width, height))
+	}
+	for y := 0; y < height; y++ {
+		src := o.pixels.Pix[(height-1-y)*width : (height-y)*width]
+		dst := img.Pix[y*img.Stride:]
+		for x, c := range src {
+			dst[x*4], dst[x*4+1], dst[x*4+2], dst[x*4+3] = c.R, c.G, c.B, c.A
+		}
+	}
+	return img
+}
+
+// flipRows copies rows of pixel data in reverse order.
+// OpenGL stores rows bottom to top, while images are stored top to bottom.
+func flipRows(src, dst []uint8, stride int) {
+	rows := len(dst) / stride
+	for r := 0; r < rows; r++ {
+		copy(dst[r*stride:(r+1)*stride], src[(rows-r-1)*stride:(rows-r)*stride])
+	}
+}
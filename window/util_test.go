@@ -16,7 +16,7 @@ func TestScale(t *testing.T) {
 		panic(err)
 	}
 
-	scale := window.Scale(w, 400, 300)
+	scale := window.Scale(window.NewContext(w, w.Canvas().Bounds(), w), 400, 300)
 
 	assert.Equal(t, 2.0, scale)
 }
@@ -2,22 +2,26 @@ package window
 
 import (
 	"fmt"
+	"image"
 	"log"
 
 	pixel "github.com/gopxl/pixel/v2"
 	"github.com/gopxl/pixel/v2/backends/opengl"
+	"github.com/gopxl/pixel/v2/ext/text"
 	"github.com/mlange-42/arche-model/resource"
 	"github.com/mlange-42/arche/ecs"
 	"github.com/mlange-42/arche/generic"
-	"golang.org/x/image/colornames"
 )
 
 // Drawer interface.
 // Drawers are used by the [Window] to render information from an Arche model.
+//
+// Drawers draw to a [Context], which provides the drawing target, its bounds, and user input.
+// For drawers that work directly on an OpenGL window, see [WindowDrawer] and [Adapt].
 type Drawer interface {
 	// Initialize is called before any other method.
 	// Use it to initialize the Drawer.
-	Initialize(w *ecs.World, win *opengl.Window)
+	Initialize(w *ecs.World, ctx *Context)
 
 	// Update is called with normal system updates.
 	// Can be used to update observers.
@@ -25,12 +29,40 @@ type Drawer interface {
 
 	// UpdateInputs is called on every UI update, i.e. with the frequency of FPS.
 	// Can be used to handle user input of the previous frame update.
-	UpdateInputs(w *ecs.World, win *opengl.Window)
+	UpdateInputs(w *ecs.World, ctx *Context)
 
 	// Draw is called on UI updates, every [Model.DrawInterval] steps.
 	// Draw is not called when the host window is minimized.
-	// Do all OpenGL drawing on the window here.
-	Draw(w *ecs.World, win *opengl.Window)
+	// Do all drawing on the context here.
+	Draw(w *ecs.World, ctx *Context)
+}
+
+// Finalizer is an optional interface for a [Drawer].
+// Use it to release resources, flush output or close files.
+//
+// Finalize is called on UI finalization, before the window is destroyed.
+type Finalizer interface {
+	Finalize(w *ecs.World)
+}
+
+// Resizer is an optional interface for a [Drawer].
+// Use it to re-calculate cached layouts.
+//
+// Resize is called when the size of the drawer's drawing area changes,
+// before the next call to UpdateInputs or Draw.
+// It is not called for the initial size, which is known in Initialize.
+type Resizer interface {
+	Resize(w *ecs.World, ctx *Context)
+}
+
+// Hitter is an optional interface for a [Drawer] with interactive regions, like buttons.
+//
+// Mouse events inside an interactive region are consumed by the drawer,
+// and are not seen by drawers below it in z order.
+// Drawers that don't implement Hitter do not consume any events.
+type Hitter interface {
+	// Hit returns whether the given position, relative to the context, is inside an interactive region.
+	Hit(ctx *Context, pos pixel.Vec) bool
 }
 
 // Bounds define a bounding box for a window.
@@ -52,15 +84,66 @@ func B(x, y, w, h int) Bounds {
 //
 // If the world contains a resource of type [github.com/mlange-42/arche-model/resource/Termination],
 // the model is terminated when the window is closed.
+//
+// A headless window has no GLFW window, and requires neither a display nor an OpenGL context.
+// It renders in software to an [Offscreen] target, and the frame of every re-draw can be retrieved with [Window.Frame].
+// A headless window receives no user input, and drawers wrapped with [Adapt] are not supported.
+//
+// Drawers register their keyboard shortcuts in the window's [Bindings].
+// Keys can be remapped by action name via Keys, or via a JSON file given by KeysFile (see [Bindings.Load]).
+// Keys that are bound to multiple actions are reported on initialization.
+//
+// Mouse events are passed to drawers from the top of the z order downwards,
+// until they are consumed by a drawer implementing [Hitter].
+// Keyboard events are passed to all drawers, unless a drawer has the keyboard focus.
+// The focus is switched between drawers with TAB and SHIFT+TAB, cycling through a state without focus.
+// The focused drawer is shown in the top left corner of the window.
+//
+// Pressing F1 shows an overlay listing the shortcuts of all drawers that implement [Describer].
+//
+// Pressing F12 saves a screenshot of the window as PNG, named after the window title, the model tick
+// and the number of the screenshot, like Arche_000120_00003.png.
+// Further, drawers implementing [VectorExporter] are saved as vector graphics, like Arche_000120_00003_1.svg.
+// If the world contains no resource of type [github.com/mlange-42/arche-model/resource.Tick],
+// the draw step is used instead of the tick.
+//
+// User input is published to ordinary systems via the ECS resource [InputState].
+//
+// Drawers use the colors of the window's [Theme], and text is drawn with the window's Font.
+// Both are passed to drawers via [Context].
+// The default bitmap font covers only ASCII characters, and is small on high-resolution screens.
+// For other characters and for larger text, use a TrueType or OpenType font.
 type Window struct {
-	Title        string   // Window title. Optional.
-	Bounds       Bounds   // Window bounds (position and size). Optional.
-	Drawers      []Drawer // Drawers in increasing z order.
-	DrawInterval int      // Interval for re-drawing, in UI frames. Optional.
-	window       *opengl.Window
-	drawStep     int64
-	isClosed     bool
-	termRes      generic.Resource[resource.Termination]
+	Title          string                  // Window title. Optional.
+	Bounds         Bounds                  // Window bounds (position and size). Optional.
+	Drawers        []Drawer                // Drawers in increasing z order.
+	DrawInterval   int                     // Interval for re-drawing, in UI frames. Optional.
+	Headless       bool                    // Whether to render offscreen, without a GLFW window, e.g. for capturing frames with [Window.Frame]. Optional.
+	Keys           map[string]pixel.Button // Keys for actions, overriding the drawers' defaults. Optional.
+	KeysFile       string                  // JSON file with keys for actions, overriding the drawers' defaults. Optional.
+	ScreenshotDir  string                  // Directory for screenshots. Optional, default current working directory.
+	VectorFormat   string                  // File format for vector graphics of screenshots: "svg", "pdf" or "eps". Optional, default "svg".
+	Theme          *Theme                  // Colors and font for all drawers. Optional, default [LightTheme].
+	Font           *text.Atlas             // Font for all drawers, see e.g. [LoadFont] and [GoFont]. Overrides the theme's font. Optional.
+	window         *opengl.Window
+	offscreen      *Offscreen
+	context        *Context
+	inputs         []*Context
+	focus          int
+	focusKey       *Action
+	focusText      *text.Text
+	helpKey        *Action
+	help           helpOverlay
+	showHelp       bool
+	shotKey        *Action
+	takeScreenshot bool
+	shots          int
+	tickRes        generic.Resource[resource.Tick]
+	inputRes       generic.Resource[InputState]
+	frame          *image.RGBA
+	drawStep       int64
+	isClosed       bool
+	termRes        generic.Resource[resource.Termination]
 }
 
 // With adds one or more [Drawer] instances to the window.
@@ -83,6 +166,71 @@ func (w *Window) InitializeUI(world *ecs.World) {
 	if w.Title == "" {
 		w.Title = "Arche"
 	}
+	if w.VectorFormat == "" {
+		w.VectorFormat = "svg"
+	}
+	if w.Headless {
+		w.window = nil
+		w.offscreen = NewOffscreen(pixel.R(0, 0, float64(w.Bounds.W), float64(w.Bounds.H)))
+		w.context = NewContext(w.offscreen, w.offscreen.Bounds(), nil)
+	} else {
+		w.offscreen = nil
+		w.initializeWindow()
+	}
+	if w.Theme == nil {
+		w.Theme = LightTheme()
+	}
+	w.context.Theme = w.Theme
+	if w.Font != nil {
+		w.context.Font = w.Font
+	} else if w.Theme.Font != nil {
+		w.context.Font = w.Theme.Font
+	}
+
+	if w.KeysFile != "" {
+		if err := w.context.Bindings.Load(w.KeysFile); err != nil {
+			panic(err)
+		}
+	}
+	for name, key := range w.Keys {
+		w.context.Bindings.Set(name, key)
+	}
+	w.inputRes = generic.NewResource[InputState](world)
+	if !w.inputRes.Has() {
+		w.inputRes.Add(&InputState{})
+	}
+
+	w.focusKey = w.context.Bindings.Register("window.focus", pixel.KeyTab, "Switch keyboard focus between drawers")
+	w.helpKey = w.context.Bindings.Register("window.help", pixel.KeyF1, "Show or hide this help")
+	w.shotKey = w.context.Bindings.Register("window.screenshot", pixel.KeyF12, "Save a screenshot")
+
+	for _, d := range w.Drawers {
+		d.Initialize(world, w.context)
+	}
+
+	for _, msg := range w.context.Bindings.conflictMessages() {
+		log.Printf("WARNING: window '%s': %s", w.Title, msg)
+	}
+
+	w.inputs = make([]*Context, len(w.Drawers))
+	for i := range w.inputs {
+		w.inputs[i] = &Context{}
+	}
+	w.focus = -1
+	w.focusText = text.New(pixel.V(0, 0), w.context.Font)
+	w.focusText.Color = w.Theme.Foreground
+	w.help = newHelpOverlay(w.context.Font)
+	w.showHelp = false
+
+	w.termRes = generic.NewResource[resource.Termination](world)
+	w.tickRes = generic.NewResource[resource.Tick](world)
+	w.frame = nil
+	w.drawStep = 0
+	w.isClosed = false
+}
+
+// initializeWindow creates the GLFW window, and the drawing context for it.
+func (w *Window) initializeWindow() {
 	cfg := opengl.WindowConfig{
 		Title:     w.Title,
 		Bounds:    pixel.R(0, 0, float64(w.Bounds.W), float64(w.Bounds.H)),
@@ -105,13 +253,8 @@ func (w *Window) InitializeUI(world *ecs.World) {
 	if err != nil {
 		panic(err)
 	}
-	for _, d := range w.Drawers {
-		d.Initialize(world, w.window)
-	}
-
-	w.termRes = generic.NewResource[resource.Termination](world)
-	w.drawStep = 0
-	w.isClosed = false
+	w.context = NewContext(w.window, w.window.Canvas().Bounds(), w.window)
+	w.context.window = w.window
 }
 
 // Update the window system.
@@ -126,7 +269,7 @@ func (w *Window) Update(world *ecs.World) {
 
 // UpdateUI the window system.
 func (w *Window) UpdateUI(world *ecs.World) {
-	if w.window.Closed() {
+	if w.window != nil && w.window.Closed() {
 		if !w.isClosed {
 			term := w.termRes.Get()
 			if term != nil {
@@ -137,25 +280,114 @@ func (w *Window) UpdateUI(world *ecs.World) {
 		return
 	}
 	if !w.isMinimized() && (w.DrawInterval <= 1 || w.drawStep%int64(w.DrawInterval) == 0) {
-		w.window.Clear(colornames.Black)
+		w.updateBounds(world)
+		w.context.Clear(w.Theme.Background)
 
 		for _, d := range w.Drawers {
-			d.Draw(world, w.window)
+			d.Draw(world, w.context)
+		}
+		if w.takeScreenshot {
+			w.screenshot(world)
+			w.takeScreenshot = false
+		}
+		if w.focus >= 0 {
+			w.focusText.Clear()
+			fmt.Fprintf(w.focusText, "Focus: %s", typeName(w.Drawers[w.focus]))
+			w.focusText.Draw(w.context, pixel.IM.Moved(pixel.V(10, w.context.Bounds.H()-20)))
+		}
+		if w.showHelp {
+			w.help.Draw(w.context, collectShortcuts(w.Drawers, []helpGroup{{Title: "Window", Shortcuts: w.shortcuts()}}))
+		}
+
+		if w.Headless {
+			w.frame = targetImage(w.offscreen, w.frame)
 		}
 	}
 	w.drawStep++
 }
 
+// Frame returns the image of the last re-draw of a headless window.
+// Returns nil if the window is not headless, or if it was not drawn yet.
+//
+// The returned image is re-used by subsequent re-draws.
+// Copy it if it is required to persist.
+func (w *Window) Frame() *image.RGBA {
+	return w.frame
+}
+
+// tick returns the current model tick, or the draw step if there is no tick resource.
+func (w *Window) tick() int64 {
+	if w.tickRes.Has() {
+		return w.tickRes.Get().Tick
+	}
+	return w.drawStep
+}
+
+// updateBounds updates the bounds of the drawing context, and notifies drawers about size changes.
+func (w *Window) updateBounds(world *ecs.World) {
+	if w.window == nil {
+		return
+	}
+	bounds := w.window.Canvas().Bounds()
+	if bounds == w.context.Bounds {
+		return
+	}
+	w.context.Bounds = bounds
+	for _, d := range w.Drawers {
+		resize(world, d, w.context)
+	}
+}
+
 func (w *Window) isMinimized() bool {
+	if w.window == nil {
+		return false
+	}
 	b := w.window.Bounds()
 	return b.W() <= 0 || b.H() <= 0
 }
 
 // PostUpdateUI updates the underlying GL window and input events.
 func (w *Window) PostUpdateUI(world *ecs.World) {
-	w.window.Update()
-	for _, d := range w.Drawers {
-		d.UpdateInputs(world, w.window)
+	if w.window != nil {
+		w.window.Update()
+		if !w.isMinimized() {
+			w.updateBounds(world)
+		}
+		if w.window.Focused() || w.window.MouseInsideWindow() {
+			w.inputRes.Get().update(w.context, w.window.MouseInsideWindow(), w.tick())
+		}
+	}
+	if w.context.JustPressed(w.helpKey.Key) {
+		w.showHelp = !w.showHelp
+	}
+	if w.context.JustPressed(w.shotKey.Key) {
+		w.Screenshot()
+	}
+	if w.context.JustPressed(w.focusKey.Key) {
+		w.switchFocus(w.context.Pressed(pixel.KeyLeftShift) || w.context.Pressed(pixel.KeyRightShift))
+	}
+
+	updateInputs(world, w.Drawers, w.context, w.inputs, w.focus)
+}
+
+// shortcuts returns the shortcuts of the window itself.
+func (w *Window) shortcuts() []Shortcut {
+	return []Shortcut{
+		w.helpKey.Shortcut(),
+		w.shotKey.Shortcut(),
+		w.focusKey.Shortcut(),
+		{Keys: "Shift+" + w.focusKey.Key.String(), Description: "Switch keyboard focus backwards"},
+	}
+}
+
+// switchFocus switches the keyboard focus to the next or previous drawer.
+// Cycles through a state where no drawer has the focus.
+func (w *Window) switchFocus(backwards bool) {
+	n := len(w.Drawers) + 1
+	if backwards {
+		w.focus = (w.focus+n)%n - 1
+	} else {
+		w.focus = (w.focus+2)%n - 1
 	}
 }
 
@@ -164,5 +396,51 @@ func (w *Window) Finalize(world *ecs.World) {}
 
 // FinalizeUI the window system.
 func (w *Window) FinalizeUI(world *ecs.World) {
-	w.window.Destroy()
+	for _, d := range w.Drawers {
+		finalize(world, d)
+	}
+	if w.window != nil {
+		w.window.Destroy()
+	}
+}
+
+// finalize calls [Finalizer.Finalize] if the drawer implements it.
+func finalize(w *ecs.World, d Drawer) {
+	if f, ok := d.(Finalizer); ok {
+		f.Finalize(w)
+	}
+}
+
+// updateInputs passes user input to drawers, using a separate context per drawer.
+// Mouse events are passed from the top of the z order downwards, until they are consumed by a [Hitter].
+// Keyboard events are passed only to the drawer with the focus index, or to all drawers if it is negative.
+func updateInputs(world *ecs.World, drawers []Drawer, ctx *Context, inputs []*Context, focus int) {
+	mouse := true
+	pos := ctx.MousePosition()
+	for i := len(drawers) - 1; i >= 0; i-- {
+		input := inputs[i]
+		*input = *ctx
+		input.Input = filteredInput{Input: ctx.Input, mouse: mouse, keys: focus < 0 || focus == i}
+		if mouse && hit(drawers[i], input, pos) {
+			mouse = false
+		}
+	}
+	for i, d := range drawers {
+		d.UpdateInputs(world, inputs[i])
+	}
+}
+
+// hit calls [Hitter.Hit] if the drawer implements it.
+func hit(d Drawer, ctx *Context, pos pixel.Vec) bool {
+	if h, ok := d.(Hitter); ok {
+		return h.Hit(ctx, pos)
+	}
+	return false
+}
+
+// resize calls [Resizer.Resize] if the drawer implements it.
+func resize(w *ecs.World, d Drawer, ctx *Context) {
+	if r, ok := d.(Resizer); ok {
+		r.Resize(w, ctx)
+	}
 }
@@ -1,8 +1,17 @@
 package window_test
 
 import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pixel "github.com/gopxl/pixel/v2"
 	"github.com/mlange-42/arche-model/model"
+	"github.com/mlange-42/arche-model/system"
 	"github.com/mlange-42/arche-pixel/window"
+	"github.com/mlange-42/arche/ecs"
+	"github.com/stretchr/testify/assert"
 )
 
 func ExampleWindow() {
@@ -16,3 +25,180 @@ func ExampleWindow() {
 	m.AddUISystem(window)
 	// Output:
 }
+
+func TestWindow_Headless(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	win := (&window.Window{
+		Bounds:   window.B(0, 0, 400, 300),
+		Headless: true,
+		Theme:    window.DarkTheme(),
+	}).With(&RectDrawer{})
+	m.AddUISystem(win)
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	m.Run()
+
+	frame := win.Frame()
+	assert.NotNil(t, frame)
+	assert.Equal(t, 400, frame.Rect.Dx())
+	assert.Equal(t, 300, frame.Rect.Dy())
+
+	assert.Equal(t, color.RGBAModel.Convert(color.White), frame.RGBAAt(150, 150))
+	assert.Equal(t, color.RGBAModel.Convert(win.Theme.Background), frame.RGBAAt(10, 10))
+}
+
+func TestWindow_Finalize(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	drawer := LifecycleDrawer{}
+	grid := (&window.Grid{}).With(window.Cell{Drawer: &drawer})
+	m.AddUISystem((&window.Window{}).With(grid))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	m.Run()
+
+	assert.Equal(t, 1, drawer.Finalized)
+	assert.Equal(t, 0, drawer.Resized)
+}
+
+func TestWindow_Resize(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	drawer := LifecycleDrawer{ResizeTo: pixel.R(0, 0, 500, 400)}
+	m.AddUISystem((&window.Window{Bounds: window.B(0, 0, 400, 300)}).With(&drawer))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 30,
+	})
+	m.Run()
+
+	assert.Equal(t, 1, drawer.Resized)
+	assert.Equal(t, pixel.R(0, 0, 500, 400), drawer.Bounds)
+}
+
+// LifecycleDrawer counts calls to optional lifecycle methods.
+type LifecycleDrawer struct {
+	ResizeTo  pixel.Rect
+	Bounds    pixel.Rect
+	Finalized int
+	Resized   int
+}
+
+func (d *LifecycleDrawer) Initialize(w *ecs.World, ctx *window.Context) {
+	d.Bounds = ctx.Bounds
+}
+
+func (d *LifecycleDrawer) Update(w *ecs.World) {}
+
+func (d *LifecycleDrawer) UpdateInputs(w *ecs.World, ctx *window.Context) {}
+
+func (d *LifecycleDrawer) Draw(w *ecs.World, ctx *window.Context) {
+	if d.ResizeTo.Area() > 0 && ctx.Window().Bounds() != d.ResizeTo {
+		ctx.Window().SetBounds(d.ResizeTo)
+	}
+}
+
+func (d *LifecycleDrawer) Resize(w *ecs.World, ctx *window.Context) {
+	d.Bounds = ctx.Bounds
+	d.Resized++
+}
+
+func (d *LifecycleDrawer) Finalize(w *ecs.World) {
+	d.Finalized++
+}
+
+func TestWindow_Screenshot(t *testing.T) {
+	dir := t.TempDir()
+
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	win := (&window.Window{
+		Title:         "Test window",
+		ScreenshotDir: dir,
+		VectorFormat:  "txt",
+	}).With(
+		&RectDrawer{},
+		(&window.Grid{}).With(window.Cell{Drawer: &ExportDrawer{}}),
+	)
+	win.Screenshot()
+	m.AddUISystem(win)
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	m.Run()
+
+	files, err := filepath.Glob(filepath.Join(dir, "Test_window_*"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(files))
+	assert.Equal(t, ".png", filepath.Ext(files[0]))
+	assert.Equal(t, "_1.txt", files[1][len(files[1])-6:])
+}
+
+func TestWindow_ScreenshotHidden(t *testing.T) {
+	dir := t.TempDir()
+
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	win := (&window.Window{
+		Title:         "Test window",
+		ScreenshotDir: dir,
+		VectorFormat:  "txt",
+	}).With(
+		(&window.Tabs{}).With(
+			window.Tab{Drawer: &ExportDrawer{}},
+			window.Tab{Drawer: &ExportDrawer{}},
+		),
+		&window.Toggle{Drawer: &ExportDrawer{}, Name: "export", Hidden: true},
+	)
+	win.Screenshot()
+	m.AddUISystem(win)
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	m.Run()
+
+	files, err := filepath.Glob(filepath.Join(dir, "Test_window_*.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(files))
+}
+
+// ExportDrawer writes its bounds to a file on vector export.
+type ExportDrawer struct {
+	LifecycleDrawer
+}
+
+func (d *ExportDrawer) ExportVector(w *ecs.World, ctx *window.Context, path string) error {
+	return os.WriteFile(path, []byte(ctx.Bounds.String()), 0644)
+}
+
+func TestWindow_InputState(t *testing.T) {
+	m := model.New()
+	m.TPS = 300
+	m.FPS = 0
+
+	m.AddUISystem((&window.Window{}).With(&RectDrawer{}))
+
+	m.AddSystem(&system.FixedTermination{
+		Steps: 10,
+	})
+	m.Run()
+
+	assert.True(t, ecs.GetResource[window.InputState](&m.World) != nil)
+}